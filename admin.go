@@ -3,6 +3,7 @@ package powerbi
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -21,6 +22,8 @@ type Admin interface {
 
 type Groups interface {
 	AddUserAsAdmin(ctx context.Context, groupID string, req types.GroupUser) error
+	AllGroupsAsAdmin(ctx context.Context, opts types.GroupsOptions) iter.Seq2[types.AdminGroup, error]
+	AllUnusedArtifactsAsAdmin(ctx context.Context, groupID string, opts types.UnusedArtifactsOptions) iter.Seq2[types.UnusedArtifactEntity, error]
 	DeleteUserAsAdmin(ctx context.Context, groupID string, userID string, opts types.DeleteUserOptions) error
 	GetGroupAsAdmin(ctx context.Context, groupID string, opts types.GroupOptions) (*types.AdminGroup, error)
 	GetGroupUsersAsAdmin(ctx context.Context, groupID string) ([]types.GroupUser, error)
@@ -38,6 +41,7 @@ func (s *AdminService) Groups() Groups {
 // AddUserAsAdmin grants user permissions to the specified workspace.
 // This API call only supports adding a user, security group, M365 group and service principal.
 func (s *groupService) AddUserAsAdmin(ctx context.Context, groupID string, req types.GroupUser) error {
+	ctx = withOperation(ctx, "Admin.Groups.AddUserAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s/users", adminBasePath, groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -48,10 +52,61 @@ func (s *groupService) AddUserAsAdmin(ctx context.Context, groupID string, req t
 	return nil
 }
 
+// AllGroupsAsAdmin returns an iterator over all workspaces of the organization,
+// fetching them page by page. opts.Top sets the page size, which defaults to
+// and is capped at 5000, and opts.Skip the initial offset.
+func (s *groupService) AllGroupsAsAdmin(ctx context.Context, opts types.GroupsOptions) iter.Seq2[types.AdminGroup, error] {
+	ctx = withOperation(ctx, "Admin.Groups.AllGroupsAsAdmin")
+	if opts.Top <= 0 || opts.Top > maxAdminPageSize {
+		opts.Top = maxAdminPageSize
+	}
+	return offsetPager(ctx, opts.Top, opts.Skip, func(ctx context.Context, top, skip int) ([]types.AdminGroup, error) {
+		opts := opts
+		opts.Top, opts.Skip = top, skip
+		return s.getGroupsAsAdmin(ctx, opts)
+	})
+}
+
+// AllUnusedArtifactsAsAdmin returns an iterator over all datasets, reports, and
+// dashboards that have not been used within 30 days for the specified workspace,
+// following the continuation links returned by GetUnusedArtifactsAsAdmin.
+func (s *groupService) AllUnusedArtifactsAsAdmin(ctx context.Context, groupID string, opts types.UnusedArtifactsOptions) iter.Seq2[types.UnusedArtifactEntity, error] {
+	ctx = withOperation(ctx, "Admin.Groups.AllUnusedArtifactsAsAdmin")
+	u := fmt.Sprintf("%s/%s/%s/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID), "unused")
+	first, err := addOptions(u, opts)
+	if err != nil {
+		return func(yield func(types.UnusedArtifactEntity, error) bool) {
+			yield(types.UnusedArtifactEntity{}, err)
+		}
+	}
+
+	return continuationPager(ctx, first, func(ctx context.Context, next string) ([]types.UnusedArtifactEntity, string, error) {
+		_, resp, err := s.client.doRequest(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+
+		var result types.UnusedArtifactsResponse
+		if _, err := toObject(resp, &result); err != nil {
+			return nil, "", err
+		}
+		if result.ContinuationURI != "" {
+			return result.UnusedArtifactEntities, result.ContinuationURI, nil
+		}
+		if result.ContinuationToken != "" {
+			next, err := addOptions(u, types.UnusedArtifactsOptions{ContinuationToken: result.ContinuationToken})
+			return result.UnusedArtifactEntities, next, err
+		}
+		return result.UnusedArtifactEntities, "", nil
+	})
+}
+
 // DeleteUserAsAdmin removes user permissions from the specified workspace.
 // This API call supports removing a user, security group, M365 group and service principal.
 // Please use email address or UPN for user, group object Id for group and app object Id for service principal to delete.
 func (s *groupService) DeleteUserAsAdmin(ctx context.Context, groupID string, userID string, opts types.DeleteUserOptions) error {
+	ctx = withOperation(ctx, "Admin.Groups.DeleteUserAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s/%s/users/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID), "users", url.PathEscape(userID))
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -68,6 +123,7 @@ func (s *groupService) DeleteUserAsAdmin(ctx context.Context, groupID string, us
 
 // GetGroupAsAdmin returns a workspace for the organization.
 func (s *groupService) GetGroupAsAdmin(ctx context.Context, groupID string, opts types.GroupOptions) (*types.AdminGroup, error) {
+	ctx = withOperation(ctx, "Admin.Groups.GetGroupAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID))
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -85,6 +141,7 @@ func (s *groupService) GetGroupAsAdmin(ctx context.Context, groupID string, opts
 
 // GetGroupUsersAsAdmin returns a list of users that have access to the specified workspace.
 func (s *groupService) GetGroupUsersAsAdmin(ctx context.Context, groupID string) ([]types.GroupUser, error) {
+	ctx = withOperation(ctx, "Admin.Groups.GetGroupUsersAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID), "users")
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -102,6 +159,12 @@ func (s *groupService) GetGroupUsersAsAdmin(ctx context.Context, groupID string)
 
 // GetGroupsAsAdmin returns a list of workspaces for the organization.
 func (s *groupService) GetGroupsAsAdmin(ctx context.Context, opts types.GroupsOptions) ([]types.AdminGroup, error) {
+	ctx = withOperation(ctx, "Admin.Groups.GetGroupsAsAdmin")
+	return s.getGroupsAsAdmin(ctx, opts)
+}
+
+// getGroupsAsAdmin fetches a page of workspaces with the operation of ctx.
+func (s *groupService) getGroupsAsAdmin(ctx context.Context, opts types.GroupsOptions) ([]types.AdminGroup, error) {
 	u := fmt.Sprintf("%s/%s", adminBasePath, groupsBasePath)
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -126,6 +189,7 @@ func (s *groupService) GetGroupsAsAdmin(ctx context.Context, opts types.GroupsOp
 // GetUnusedArtifactsAsAdmin returns a list of datasets, reports, and dashboards
 // that have not been used within 30 days for the specified workspace. This is a preview API call.
 func (s *groupService) GetUnusedArtifactsAsAdmin(ctx context.Context, groupID string, opts types.UnusedArtifactsOptions) (*types.UnusedArtifactsResponse, error) {
+	ctx = withOperation(ctx, "Admin.Groups.GetUnusedArtifactsAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID), "unused")
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -143,6 +207,7 @@ func (s *groupService) GetUnusedArtifactsAsAdmin(ctx context.Context, groupID st
 
 // RestoreDeletedGroupAsAdmin restores a deleted workspace.
 func (s *groupService) RestoreDeletedGroupAsAdmin(ctx context.Context, groupID string, req types.GroupRestoreRequest) error {
+	ctx = withOperation(ctx, "Admin.Groups.RestoreDeletedGroupAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID), "restore")
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -155,6 +220,7 @@ func (s *groupService) RestoreDeletedGroupAsAdmin(ctx context.Context, groupID s
 
 // UpdateGroupAsAdmin updates the properties of the specified workspace.
 func (s *groupService) UpdateGroupAsAdmin(ctx context.Context, groupID string, req types.AdminGroup) error {
+	ctx = withOperation(ctx, "Admin.Groups.UpdateGroupAsAdmin")
 	u := fmt.Sprintf("%s/%s/%s", adminBasePath, groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.patchJSON(ctx, u, req)
 	if err != nil {
@@ -0,0 +1,46 @@
+// Package azauth adapts Azure SDK credentials, such as those provided by the
+// azidentity package, to powerbi.TokenProvider.
+//
+// It lives in its own package so that the core powerbi package does not depend
+// on the Azure SDK.
+package azauth
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/stpabhi/powerbi-go"
+)
+
+// NewTokenProvider returns a powerbi.TokenProvider that fetches tokens from
+// cred. If no scopes are given, powerbi.DefaultScope is requested.
+func NewTokenProvider(cred azcore.TokenCredential, scopes ...string) powerbi.TokenProvider {
+	if len(scopes) == 0 {
+		scopes = []string{powerbi.DefaultScope}
+	}
+	return powerbi.TokenProviderFunc(func(ctx context.Context) (powerbi.Token, error) {
+		t, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: scopes})
+		if err != nil {
+			return powerbi.Token{}, err
+		}
+		return powerbi.Token{AccessToken: t.Token, ExpiresOn: t.ExpiresOn}, nil
+	})
+}
+
+// NewClient returns a new Power BI API client that authenticates requests with
+// tokens from cred. Tokens are cached and refreshed ahead of their expiry.
+func NewClient(cred azcore.TokenCredential, scopes ...string) *powerbi.Client {
+	return powerbi.NewFromTokenProvider(NewTokenProvider(cred, scopes...))
+}
+
+// NewClientForCloud returns a new Power BI API client for cloud that
+// authenticates requests with tokens for the scope of cloud from cred.
+func NewClientForCloud(cred azcore.TokenCredential, cloud powerbi.Cloud) (*powerbi.Client, error) {
+	c := powerbi.NewFromTokenProvider(NewTokenProvider(cred, cloud.Scope))
+	if err := c.SetCloud(cloud); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
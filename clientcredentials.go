@@ -0,0 +1,329 @@
+package powerbi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// DefaultAuthorityURL is the Microsoft Entra ID authority of the public cloud.
+// See Cloud for the authorities of the other clouds.
+const DefaultAuthorityURL = "https://login.microsoftonline.com/"
+
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// ClientCredentials authenticates a service principal with the Microsoft Entra
+// ID client credentials flow, using either a client secret or a certificate.
+// ClientCredentials implements TokenProvider.
+type ClientCredentials struct {
+	TenantID string
+	ClientID string
+
+	// ClientSecret authenticates the application with a client secret.
+	ClientSecret string
+
+	// Certificates and PrivateKey authenticate the application with a signed
+	// client assertion. The first certificate must be the one matching
+	// PrivateKey, which must be an RSA key. See ParseCertificates.
+	Certificates []*x509.Certificate
+	PrivateKey   crypto.PrivateKey
+
+	// Cloud is the Power BI cloud the service principal authenticates to.
+	// It determines the default authority and scope, and the REST API base
+	// URL of clients created by NewFromClientCredentials. It defaults to
+	// CloudPublic.
+	Cloud Cloud
+
+	// AuthorityURL overrides the Microsoft Entra ID authority of Cloud.
+	AuthorityURL string
+
+	// Scopes overrides the requested scope of Cloud.
+	Scopes []string
+
+	// HTTPClient is used for token requests. It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+var _ TokenProvider = &ClientCredentials{}
+
+// NewFromClientCredentials returns a new Power BI API client authenticated as
+// the service principal described by cc. Tokens are cached and refreshed
+// ahead of their expiry.
+func NewFromClientCredentials(cc ClientCredentials) (*Client, error) {
+	if err := cc.validate(); err != nil {
+		return nil, err
+	}
+	c := NewFromTokenProvider(&cc)
+	if err := c.SetCloud(cc.cloud()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (cc *ClientCredentials) cloud() Cloud {
+	if cc.Cloud.APIBaseURL == "" {
+		return CloudPublic
+	}
+	return cc.Cloud
+}
+
+func (cc *ClientCredentials) validate() error {
+	if cc.TenantID == "" {
+		return errors.New("powerbi: client credentials require a tenant ID")
+	}
+	if cc.ClientID == "" {
+		return errors.New("powerbi: client credentials require a client ID")
+	}
+	hasCert := len(cc.Certificates) > 0 || cc.PrivateKey != nil
+	if (cc.ClientSecret != "") == hasCert {
+		return errors.New("powerbi: client credentials require either a client secret or a certificate")
+	}
+	if hasCert {
+		if len(cc.Certificates) == 0 {
+			return errors.New("powerbi: client certificate is missing")
+		}
+		if _, ok := cc.PrivateKey.(*rsa.PrivateKey); !ok {
+			return errors.New("powerbi: client certificate private key must be an RSA key")
+		}
+	}
+	return nil
+}
+
+// GetToken requests a new access token from the token endpoint.
+func (cc *ClientCredentials) GetToken(ctx context.Context) (Token, error) {
+	if err := cc.validate(); err != nil {
+		return Token{}, err
+	}
+
+	endpoint := cc.tokenEndpoint()
+	scopes := cc.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{cc.cloud().Scope}
+	}
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"client_id":  {cc.ClientID},
+		"scope":      {strings.Join(scopes, " ")},
+	}
+	if cc.ClientSecret != "" {
+		form.Set("client_secret", cc.ClientSecret)
+	} else {
+		assertion, err := cc.clientAssertion(endpoint, time.Now())
+		if err != nil {
+			return Token{}, err
+		}
+		form.Set("client_assertion_type", clientAssertionType)
+		form.Set("client_assertion", assertion)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", mediaType)
+
+	httpClient := cc.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Token{}, err
+	}
+
+	var result struct {
+		AccessToken      string      `json:"access_token"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	if resp.StatusCode > 399 {
+		errHTTP := types.NewErrHTTP(resp.StatusCode, string(data))
+		if json.Unmarshal(data, &result) == nil {
+			errHTTP.ErrorCode = result.Error
+			errHTTP.ErrorMessage = result.ErrorDescription
+		}
+		errHTTP.RequestID = resp.Header.Get("x-ms-request-id")
+		return Token{}, fmt.Errorf("powerbi: token request failed: %w", errHTTP)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return Token{}, fmt.Errorf("powerbi: decoding token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return Token{}, errors.New("powerbi: token response has no access token")
+	}
+
+	token := Token{AccessToken: result.AccessToken}
+	if secs, err := result.ExpiresIn.Int64(); err == nil {
+		token.ExpiresOn = time.Now().Add(time.Duration(secs) * time.Second)
+	}
+	return token, nil
+}
+
+func (cc *ClientCredentials) tokenEndpoint() string {
+	authority := cc.AuthorityURL
+	if authority == "" {
+		authority = cc.cloud().AuthorityURL
+	}
+	return strings.TrimSuffix(authority, "/") + "/" + url.PathEscape(cc.TenantID) + "/oauth2/v2.0/token"
+}
+
+// clientAssertion returns a JWT client assertion for audience, signed with the
+// client certificate.
+func (cc *ClientCredentials) clientAssertion(audience string, now time.Time) (string, error) {
+	cert := cc.Certificates[0]
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	header := map[string]string{
+		"alg":      "RS256",
+		"typ":      "JWT",
+		"x5t":      base64.RawURLEncoding.EncodeToString(sha1Sum[:]),
+		"x5t#S256": base64.RawURLEncoding.EncodeToString(sha256Sum[:]),
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	claims := map[string]any{
+		"aud": audience,
+		"iss": cc.ClientID,
+		"sub": cc.ClientID,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(10 * time.Minute).Unix(),
+	}
+
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, cc.PrivateKey.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// ParseCertificates loads certificates and an unencrypted private key from PEM
+// or PKCS#12 data, for use in ClientCredentials. The password is only used for
+// PKCS#12 data, which may be encrypted with the legacy RC2 and 3DES ciphers or
+// with AES, as exported by OpenSSL 3 and Azure Key Vault. The certificate
+// matching the private key is returned first.
+func ParseCertificates(data []byte, password []byte) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	certs, key, err := parsePEM(data)
+	if err == errNotPEM {
+		certs, key, err = parsePKCS12(data, password)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil, errors.New("powerbi: certificate data contains no certificate")
+	}
+	if key == nil {
+		return nil, nil, errors.New("powerbi: certificate data contains no private key")
+	}
+
+	// Move the certificate matching the key to the front.
+	if signer, ok := key.(crypto.Signer); ok {
+		for i, cert := range certs {
+			if pub, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); ok && pub.Equal(signer.Public()) {
+				certs[0], certs[i] = certs[i], certs[0]
+				break
+			}
+		}
+	}
+	return certs, key, nil
+}
+
+// errNotPEM is returned by parsePEM for data without PEM blocks.
+var errNotPEM = errors.New("powerbi: certificate data is not PEM")
+
+// parsePEM parses the certificates and private key of PEM data.
+func parsePEM(data []byte) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	var (
+		certs []*x509.Certificate
+		key   crypto.PrivateKey
+		found bool
+	)
+	for rest := data; ; {
+		var b *pem.Block
+		b, rest = pem.Decode(rest)
+		if b == nil {
+			break
+		}
+		found = true
+		switch b.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(b.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			certs = append(certs, cert)
+		case "PRIVATE KEY", "RSA PRIVATE KEY":
+			if key != nil {
+				return nil, nil, errors.New("powerbi: certificate data contains multiple private keys")
+			}
+			var err error
+			if key, err = parsePrivateKey(b.Bytes); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if !found {
+		return nil, nil, errNotPEM
+	}
+	return certs, key, nil
+}
+
+// parsePrivateKey parses a PKCS#8 or PKCS#1 private key.
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKCS1PrivateKey(der)
+}
+
+// parsePKCS12 parses the certificates and private key of PKCS#12 data.
+func parsePKCS12(data []byte, password []byte) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	key, cert, caCerts, err := pkcs12.DecodeChain(data, string(password))
+	if err != nil {
+		return nil, nil, fmt.Errorf("powerbi: certificate data is neither PEM nor PKCS#12: %w", err)
+	}
+	return append([]*x509.Certificate{cert}, caCerts...), key, nil
+}
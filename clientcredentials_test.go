@@ -0,0 +1,253 @@
+package powerbi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// newTestCertificate returns a self-signed certificate and its RSA key.
+func newTestCertificate(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "powerbi-go test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestParseCertificatesPEM(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...,
+	)
+
+	certs, got, err := ParseCertificates(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 || !certs[0].Equal(cert) {
+		t.Errorf("certificates = %v, want the test certificate", certs)
+	}
+	if !key.Equal(got) {
+		t.Error("private key does not match")
+	}
+}
+
+func TestParseCertificatesPKCS12(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	ca, _ := newTestCertificate(t)
+	for name, enc := range map[string]*pkcs12.Encoder{
+		"legacy": pkcs12.LegacyRC2,
+		"modern": pkcs12.Modern,
+	} {
+		t.Run(name, func(t *testing.T) {
+			pfx, err := enc.Encode(key, cert, []*x509.Certificate{ca}, "secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			certs, got, err := ParseCertificates(pfx, []byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(certs) != 2 || !certs[0].Equal(cert) {
+				t.Errorf("certificates = %v, want the test certificate first", certs)
+			}
+			if !key.Equal(got) {
+				t.Error("private key does not match")
+			}
+
+			if _, _, err := ParseCertificates(pfx, []byte("wrong")); err == nil {
+				t.Error("want an error for a wrong password")
+			}
+		})
+	}
+}
+
+func TestClientCredentialsSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		r.ParseForm()
+		want := map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "client",
+			"client_secret": "s3cret",
+			"scope":         DefaultScope,
+		}
+		for k, v := range want {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("%s = %q, want %q", k, got, v)
+			}
+		}
+		w.Write([]byte(`{"access_token":"tok","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	cc := &ClientCredentials{TenantID: "tenant", ClientID: "client", ClientSecret: "s3cret", AuthorityURL: srv.URL}
+	tok, err := cc.GetToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "tok" {
+		t.Errorf("token = %q, want tok", tok.AccessToken)
+	}
+	if d := time.Until(tok.ExpiresOn); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("token expires in %v, want 1h", d)
+	}
+}
+
+func TestClientCredentialsCertificate(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	var endpoint string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if got := r.PostForm.Get("client_assertion_type"); got != clientAssertionType {
+			t.Errorf("client_assertion_type = %q", got)
+		}
+		if r.PostForm.Has("client_secret") {
+			t.Error("client_secret sent with a certificate")
+		}
+		checkAssertion(t, r.PostForm.Get("client_assertion"), cert, endpoint)
+		w.Write([]byte(`{"access_token":"tok","expires_in":"3600"}`))
+	}))
+	defer srv.Close()
+	endpoint = srv.URL + "/tenant/oauth2/v2.0/token"
+
+	cc := &ClientCredentials{TenantID: "tenant", ClientID: "client", Certificates: []*x509.Certificate{cert}, PrivateKey: key, AuthorityURL: srv.URL + "/"}
+	if _, err := cc.GetToken(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkAssertion verifies the signature, header and claims of a client
+// assertion.
+func checkAssertion(t *testing.T, assertion string, cert *x509.Certificate, audience string) {
+	t.Helper()
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("assertion has %d parts, want 3", len(parts))
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], sig); err != nil {
+		t.Errorf("signature: %v", err)
+	}
+
+	var header map[string]string
+	decodeSegment(t, parts[0], &header)
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	wantHeader := map[string]string{
+		"alg":      "RS256",
+		"typ":      "JWT",
+		"x5t":      base64.RawURLEncoding.EncodeToString(sha1Sum[:]),
+		"x5t#S256": base64.RawURLEncoding.EncodeToString(sha256Sum[:]),
+	}
+	for k, v := range wantHeader {
+		if header[k] != v {
+			t.Errorf("header %s = %q, want %q", k, header[k], v)
+		}
+	}
+
+	var claims struct {
+		Aud, Iss, Sub, Jti string
+		Iat, Nbf, Exp      int64
+	}
+	decodeSegment(t, parts[1], &claims)
+	if claims.Aud != audience || claims.Iss != "client" || claims.Sub != "client" || claims.Jti == "" {
+		t.Errorf("claims = %+v, want audience %q and client as issuer and subject", claims, audience)
+	}
+	now := time.Now().Unix()
+	if claims.Iat > now || claims.Nbf > now || claims.Exp <= now || claims.Exp-claims.Iat > 600 {
+		t.Errorf("claims iat %d, nbf %d, exp %d are not valid now (%d)", claims.Iat, claims.Nbf, claims.Exp, now)
+	}
+}
+
+func decodeSegment(t *testing.T, seg string, v any) {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientCredentialsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-request-id", "req-1")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret provided."}`))
+	}))
+	defer srv.Close()
+
+	cc := &ClientCredentials{TenantID: "tenant", ClientID: "client", ClientSecret: "bad", AuthorityURL: srv.URL}
+	_, err := cc.GetToken(context.Background())
+	var errHTTP *types.ErrHTTP
+	if !errors.As(err, &errHTTP) {
+		t.Fatalf("err = %v, want *types.ErrHTTP", err)
+	}
+	if errHTTP.ErrorCode != "invalid_client" || errHTTP.RequestID != "req-1" {
+		t.Errorf("error code %q, request ID %q", errHTTP.ErrorCode, errHTTP.RequestID)
+	}
+}
+
+func TestClientCredentialsValidate(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	tests := []ClientCredentials{
+		{ClientID: "client", ClientSecret: "s"},
+		{TenantID: "tenant", ClientSecret: "s"},
+		{TenantID: "tenant", ClientID: "client"},
+		{TenantID: "tenant", ClientID: "client", ClientSecret: "s", Certificates: []*x509.Certificate{cert}, PrivateKey: key},
+		{TenantID: "tenant", ClientID: "client", PrivateKey: key},
+	}
+	for i, cc := range tests {
+		if _, err := NewFromClientCredentials(cc); err == nil {
+			t.Errorf("%d: want a validation error", i)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package powerbi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Cloud describes a Power BI cloud environment: where its REST APIs live, which
+// Microsoft Entra ID authority issues its tokens, and the scope to request.
+// https://learn.microsoft.com/en-us/power-bi/developer/embedded/embed-sample-for-customers-national-clouds
+type Cloud struct {
+	Name string
+
+	// APIBaseURL is the base URL of the REST APIs, with a trailing slash.
+	APIBaseURL string
+
+	// AuthorityURL is the Microsoft Entra ID authority, with a trailing slash.
+	AuthorityURL string
+
+	// Scope is the OAuth 2.0 scope of the REST APIs.
+	Scope string
+
+	// EmbedBaseURL is the base URL of the Power BI service used for embedding,
+	// with a trailing slash.
+	EmbedBaseURL string
+}
+
+var (
+	// CloudPublic is the Power BI public cloud.
+	CloudPublic = Cloud{
+		Name:         "Public",
+		APIBaseURL:   "https://api.powerbi.com/v1.0/myorg/",
+		AuthorityURL: DefaultAuthorityURL,
+		Scope:        DefaultScope,
+		EmbedBaseURL: "https://app.powerbi.com/",
+	}
+
+	// CloudUSGov is the Power BI US Government Community Cloud (GCC).
+	CloudUSGov = Cloud{
+		Name:         "USGov",
+		APIBaseURL:   "https://api.powerbigov.us/v1.0/myorg/",
+		AuthorityURL: "https://login.microsoftonline.com/",
+		Scope:        "https://analysis.usgovcloudapi.net/powerbi/api/.default",
+		EmbedBaseURL: "https://app.powerbigov.us/",
+	}
+
+	// CloudUSGovHigh is the Power BI US Government Community Cloud High (GCC High).
+	CloudUSGovHigh = Cloud{
+		Name:         "USGovHigh",
+		APIBaseURL:   "https://api.high.powerbigov.us/v1.0/myorg/",
+		AuthorityURL: "https://login.microsoftonline.us/",
+		Scope:        "https://high.analysis.usgovcloudapi.net/powerbi/api/.default",
+		EmbedBaseURL: "https://app.high.powerbigov.us/",
+	}
+
+	// CloudUSGovMil is the Power BI US Department of Defense cloud (DoD).
+	CloudUSGovMil = Cloud{
+		Name:         "USGovMil",
+		APIBaseURL:   "https://api.mil.powerbigov.us/v1.0/myorg/",
+		AuthorityURL: "https://login.microsoftonline.us/",
+		Scope:        "https://mil.analysis.usgovcloudapi.net/powerbi/api/.default",
+		EmbedBaseURL: "https://app.mil.powerbigov.us/",
+	}
+
+	// CloudChina is Power BI operated by 21Vianet in China.
+	CloudChina = Cloud{
+		Name:         "China",
+		APIBaseURL:   "https://api.powerbi.cn/v1.0/myorg/",
+		AuthorityURL: "https://login.chinacloudapi.cn/",
+		Scope:        "https://analysis.chinacloudapi.cn/powerbi/api/.default",
+		EmbedBaseURL: "https://app.powerbi.cn/",
+	}
+)
+
+// SetCloud points the client at the REST APIs of cloud. The API base URL must
+// be absolute; a missing trailing slash is added. Tokens must be issued for the
+// same cloud; see ClientCredentials.Cloud.
+func (c *Client) SetCloud(cloud Cloud) error {
+	u, err := url.Parse(cloud.APIBaseURL)
+	if err != nil {
+		return fmt.Errorf("powerbi: invalid API base URL for cloud %s: %w", cloud.Name, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("powerbi: API base URL %q for cloud %s is not absolute", cloud.APIBaseURL, cloud.Name)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		cloud.APIBaseURL = u.String()
+	}
+	c.BaseURL = u
+	c.Cloud = cloud
+	return nil
+}
+
+// ReportEmbedURL returns the URL to embed the specified report from the
+// specified workspace. An empty groupID refers to My workspace.
+func (c Cloud) ReportEmbedURL(groupID, reportID string) string {
+	return c.embedURL("reportEmbed", "reportId", reportID, "groupId", groupID)
+}
+
+// DashboardEmbedURL returns the URL to embed the specified dashboard from the
+// specified workspace. An empty groupID refers to My workspace.
+func (c Cloud) DashboardEmbedURL(groupID, dashboardID string) string {
+	return c.embedURL("dashboardEmbed", "dashboardId", dashboardID, "groupId", groupID)
+}
+
+// TileEmbedURL returns the URL to embed the specified tile within the specified
+// dashboard from the specified workspace. An empty groupID refers to My workspace.
+func (c Cloud) TileEmbedURL(groupID, dashboardID, tileID string) string {
+	return c.embedURL("embed", "dashboardId", dashboardID, "tileId", tileID, "groupId", groupID)
+}
+
+// QNAEmbedURL returns the URL to embed Q&A for the specified dataset from the
+// specified workspace. An empty groupID refers to My workspace.
+func (c Cloud) QNAEmbedURL(groupID, datasetID string) string {
+	return c.embedURL("qnaEmbed", "datasetId", datasetID, "groupId", groupID)
+}
+
+func (c Cloud) embedURL(path string, paramKV ...string) string {
+	q := url.Values{}
+	for i := 0; i+1 < len(paramKV); i += 2 {
+		if paramKV[i+1] != "" {
+			q.Set(paramKV[i], paramKV[i+1])
+		}
+	}
+	base := c.EmbedBaseURL
+	if base == "" {
+		base = CloudPublic.EmbedBaseURL
+	}
+	return base + path + "?" + q.Encode()
+}
@@ -0,0 +1,152 @@
+package powerbi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var testClouds = []Cloud{CloudPublic, CloudUSGov, CloudUSGovHigh, CloudUSGovMil, CloudChina}
+
+func TestCloudPresets(t *testing.T) {
+	names := map[string]bool{}
+	for _, cloud := range testClouds {
+		if names[cloud.Name] {
+			t.Errorf("duplicate cloud name %q", cloud.Name)
+		}
+		names[cloud.Name] = true
+		for field, u := range map[string]string{"APIBaseURL": cloud.APIBaseURL, "AuthorityURL": cloud.AuthorityURL, "EmbedBaseURL": cloud.EmbedBaseURL} {
+			if !strings.HasPrefix(u, "https://") || !strings.HasSuffix(u, "/") {
+				t.Errorf("%s %s = %q, want an https URL with a trailing slash", cloud.Name, field, u)
+			}
+		}
+		if !strings.HasSuffix(cloud.APIBaseURL, "/v1.0/myorg/") {
+			t.Errorf("%s APIBaseURL = %q, want the /v1.0/myorg/ path", cloud.Name, cloud.APIBaseURL)
+		}
+		if !strings.HasPrefix(cloud.Scope, "https://") || !strings.HasSuffix(cloud.Scope, "/powerbi/api/.default") {
+			t.Errorf("%s Scope = %q, want a .default scope of the Power BI API", cloud.Name, cloud.Scope)
+		}
+	}
+	if CloudPublic.AuthorityURL != DefaultAuthorityURL || CloudPublic.Scope != DefaultScope {
+		t.Errorf("CloudPublic = %+v, want the default authority and scope", CloudPublic)
+	}
+}
+
+func TestSetCloud(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"https://api.powerbigov.us/v1.0/myorg/", "https://api.powerbigov.us/v1.0/myorg/"},
+		{"https://api.powerbigov.us/v1.0/myorg", "https://api.powerbigov.us/v1.0/myorg/"},
+		{"http://localhost:8080", "http://localhost:8080/"},
+	}
+	for _, tt := range tests {
+		c := NewFromToken("tok")
+		cloud := CloudUSGov
+		cloud.APIBaseURL = tt.baseURL
+		if err := c.SetCloud(cloud); err != nil {
+			t.Errorf("SetCloud(%q) = %v", tt.baseURL, err)
+			continue
+		}
+		if got := c.BaseURL.String(); got != tt.want {
+			t.Errorf("SetCloud(%q): BaseURL = %q, want %q", tt.baseURL, got, tt.want)
+		}
+		if c.Cloud.APIBaseURL != tt.want || c.Cloud.Scope != CloudUSGov.Scope {
+			t.Errorf("SetCloud(%q): Cloud = %+v", tt.baseURL, c.Cloud)
+		}
+	}
+
+	for _, baseURL := range []string{"", "api.powerbi.com/v1.0/myorg/", "/v1.0/myorg/", "https://api.powerbi.com/%zz"} {
+		c := NewFromToken("tok")
+		cloud := CloudPublic
+		cloud.APIBaseURL = baseURL
+		if err := c.SetCloud(cloud); err == nil {
+			t.Errorf("SetCloud(%q) succeeded", baseURL)
+		}
+		if c.BaseURL.String() != CloudPublic.APIBaseURL {
+			t.Errorf("SetCloud(%q) changed BaseURL to %s", baseURL, c.BaseURL)
+		}
+	}
+}
+
+// tokenTransport answers token requests and records the last one.
+type tokenTransport struct {
+	url   string
+	scope string
+}
+
+func (tt *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.ParseForm()
+	tt.url, tt.scope = req.URL.String(), req.PostForm.Get("scope")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"access_token":"tok","expires_in":3600}`)),
+	}, nil
+}
+
+func TestClientCredentialsCloud(t *testing.T) {
+	tests := []struct {
+		cloud     Cloud
+		wantToken string
+	}{
+		{Cloud{}, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token"},
+		{CloudPublic, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token"},
+		{CloudUSGov, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token"},
+		{CloudUSGovHigh, "https://login.microsoftonline.us/tenant/oauth2/v2.0/token"},
+		{CloudUSGovMil, "https://login.microsoftonline.us/tenant/oauth2/v2.0/token"},
+		{CloudChina, "https://login.chinacloudapi.cn/tenant/oauth2/v2.0/token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cloud.Name, func(t *testing.T) {
+			transport := &tokenTransport{}
+			cc := ClientCredentials{
+				TenantID:     "tenant",
+				ClientID:     "client",
+				ClientSecret: "s3cret",
+				Cloud:        tt.cloud,
+				HTTPClient:   &http.Client{Transport: transport},
+			}
+			c, err := NewFromClientCredentials(cc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantCloud := tt.cloud
+			if wantCloud.APIBaseURL == "" {
+				wantCloud = CloudPublic
+			}
+			if c.BaseURL.String() != wantCloud.APIBaseURL || c.Cloud != wantCloud {
+				t.Errorf("client cloud %s, BaseURL %s, want %s", c.Cloud.Name, c.BaseURL, wantCloud.APIBaseURL)
+			}
+
+			if _, err := cc.GetToken(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if transport.url != tt.wantToken || transport.scope != wantCloud.Scope {
+				t.Errorf("token request to %s for scope %q, want %s for %q", transport.url, transport.scope, tt.wantToken, wantCloud.Scope)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsOverrides(t *testing.T) {
+	transport := &tokenTransport{}
+	cc := ClientCredentials{
+		TenantID:     "tenant",
+		ClientID:     "client",
+		ClientSecret: "s3cret",
+		Cloud:        CloudChina,
+		AuthorityURL: "https://login.example.com",
+		Scopes:       []string{"a", "b"},
+		HTTPClient:   &http.Client{Transport: transport},
+	}
+	if _, err := cc.GetToken(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://login.example.com/tenant/oauth2/v2.0/token"; transport.url != want || transport.scope != "a b" {
+		t.Errorf("token request to %s for scope %q, want %s for %q", transport.url, transport.scope, want, "a b")
+	}
+}
@@ -0,0 +1,232 @@
+// Command pbiquery runs a DAX query against a Power BI dataset and writes the
+// rows of its result as CSV, newline-delimited JSON, Apache Arrow or Parquet.
+//
+// Usage:
+//
+//	pbiquery -group ID -dataset ID (-query DAX | -query-file FILE) [-key COLUMNS] [-format FORMAT] [-types PAIRS] [-o FILE]
+//
+// The client authenticates with the access token in POWERBI_TOKEN or, if it is
+// unset, as the service principal of POWERBI_TENANT_ID, POWERBI_CLIENT_ID and
+// POWERBI_CLIENT_SECRET.
+//
+// Without -key, the query is a complete DAX query run in a single
+// ExecuteQueries call. With -key, it is a table expression, such as 'Sales',
+// read in windows ordered by the comma-separated key columns, such as
+// Sales[OrderID], to go past the row limits of ExecuteQueries.
+//
+// Arrow and Parquet column types are inferred from the first rows. -types
+// sets the types of some columns, as comma-separated COLUMN=TYPE pairs such
+// as Sales[OrderID]=int64, where TYPE is bool, int32, int64, float32,
+// float64, string, date32 or timestamp.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+
+	"github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/dax"
+	"github.com/stpabhi/powerbi-go/tabular"
+	"github.com/stpabhi/powerbi-go/tabular/arrowtabular"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "pbiquery:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		groupID   = flag.String("group", "", "workspace `ID`; the query runs in My workspace if empty")
+		datasetID = flag.String("dataset", "", "dataset `ID`")
+		query     = flag.String("query", "", "DAX query, or table expression with -key")
+		queryFile = flag.String("query-file", "", "read the query from `FILE`")
+		key       = flag.String("key", "", "comma-separated key `COLUMNS` to read the table expression in windows")
+		window    = flag.Int("window", 0, "rows per query with -key")
+		format    = flag.String("format", "csv", "output `FORMAT`: csv, ndjson, arrow or parquet")
+		columns   = flag.String("columns", "", "comma-separated `COLUMNS` to write, in order")
+		typeList  = flag.String("types", "", "comma-separated COLUMN=TYPE `PAIRS` setting arrow and parquet column types")
+		output    = flag.String("o", "", "write to `FILE` instead of standard output")
+	)
+	flag.Parse()
+	if *datasetID == "" {
+		return errors.New("-dataset is required")
+	}
+	if *key != "" && *groupID == "" {
+		return errors.New("-key requires -group")
+	}
+	if *queryFile != "" {
+		b, err := os.ReadFile(*queryFile)
+		if err != nil {
+			return err
+		}
+		*query = string(b)
+	}
+	if strings.TrimSpace(*query) == "" {
+		return errors.New("-query or -query-file is required")
+	}
+
+	var opts tabular.Options
+	if *columns != "" {
+		opts.Columns = splitList(*columns)
+	}
+	columnTypes, err := parseTypes(*typeList)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var rows iter.Seq2[map[string]any, error]
+	if *key != "" {
+		rows = client.Datasets.Group().QueryRows(ctx, *groupID, *datasetID, powerbi.WindowedQuery{
+			Table:        dax.Raw(*query),
+			Key:          splitList(*key),
+			WindowSize:   *window,
+			IncludeNulls: true,
+		})
+	} else {
+		var table types.DatasetExecuteQueriesTableResult
+		table, err = queryTable(ctx, client, *groupID, *datasetID, *query)
+		if err != nil {
+			return err
+		}
+		if opts.Columns == nil {
+			opts.Columns = tabular.Columns(table)
+		}
+		rows = tabular.Rows(table)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	w, err := newWriter(*format, out, opts, columnTypes)
+	if err != nil {
+		return err
+	}
+	if err := tabular.Copy(w, rows); err != nil {
+		return err
+	}
+	if out != os.Stdout {
+		return out.Close()
+	}
+	return nil
+}
+
+func newClient() (*powerbi.Client, error) {
+	if token := os.Getenv("POWERBI_TOKEN"); token != "" {
+		return powerbi.NewFromToken(token), nil
+	}
+	return powerbi.NewFromClientCredentials(powerbi.ClientCredentials{
+		TenantID:     os.Getenv("POWERBI_TENANT_ID"),
+		ClientID:     os.Getenv("POWERBI_CLIENT_ID"),
+		ClientSecret: os.Getenv("POWERBI_CLIENT_SECRET"),
+	})
+}
+
+// queryTable runs a DAX query and returns its first table, null values
+// included.
+func queryTable(ctx context.Context, client *powerbi.Client, groupID, datasetID, query string) (types.DatasetExecuteQueriesTableResult, error) {
+	req := types.DatasetExecuteQueriesRequest{
+		Queries:            []types.DatasetExecuteQueriesQuery{{Query: query}},
+		SerializerSettings: types.DatasetExecuteQueriesSerializationSettings{IncludeNulls: true},
+	}
+	var (
+		resp *types.DatasetExecuteQueriesResponse
+		err  error
+	)
+	if groupID != "" {
+		resp, err = client.Datasets.Group().ExecuteQueries(ctx, groupID, datasetID, req)
+	} else {
+		resp, err = client.Datasets.ExecuteQueries(ctx, datasetID, req)
+	}
+	if err != nil {
+		return types.DatasetExecuteQueriesTableResult{}, err
+	}
+	if len(resp.Results) == 0 || len(resp.Results[0].Tables) == 0 {
+		return types.DatasetExecuteQueriesTableResult{}, nil
+	}
+	return resp.Results[0].Tables[0], nil
+}
+
+func newWriter(format string, w io.Writer, opts tabular.Options, columnTypes map[string]arrow.DataType) (tabular.Writer, error) {
+	if columnTypes != nil && format != "arrow" && format != "parquet" {
+		return nil, fmt.Errorf("-types does not apply to format %q", format)
+	}
+	switch format {
+	case "csv":
+		return tabular.NewCSVWriter(w, opts), nil
+	case "ndjson":
+		return tabular.NewNDJSONWriter(w, opts), nil
+	case "arrow":
+		return arrowtabular.NewIPCWriter(w, arrowtabular.Options{Options: opts, Types: columnTypes}), nil
+	case "parquet":
+		return arrowtabular.NewParquetWriter(w, arrowtabular.Options{Options: opts, Types: columnTypes}), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// arrowTypes are the column types of -types.
+var arrowTypes = map[string]arrow.DataType{
+	"bool":      arrow.FixedWidthTypes.Boolean,
+	"int32":     arrow.PrimitiveTypes.Int32,
+	"int64":     arrow.PrimitiveTypes.Int64,
+	"float32":   arrow.PrimitiveTypes.Float32,
+	"float64":   arrow.PrimitiveTypes.Float64,
+	"string":    arrow.BinaryTypes.String,
+	"date32":    arrow.FixedWidthTypes.Date32,
+	"timestamp": &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+}
+
+// parseTypes parses comma-separated COLUMN=TYPE pairs.
+func parseTypes(s string) (map[string]arrow.DataType, error) {
+	if s == "" {
+		return nil, nil
+	}
+	columnTypes := make(map[string]arrow.DataType)
+	for _, pair := range splitList(s) {
+		i := strings.LastIndexByte(pair, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("-types: %q is not a COLUMN=TYPE pair", pair)
+		}
+		column, name := strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+		typ, ok := arrowTypes[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("-types: unknown type %q of column %q", name, column)
+		}
+		columnTypes[column] = typ
+	}
+	return columnTypes, nil
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
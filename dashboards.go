@@ -14,8 +14,22 @@ const dashboardsBasePath = "dashboards"
 // https://learn.microsoft.com/en-us/rest/api/power-bi/dashboards
 type DashboardsService service
 
+var _ DashboardsInterface = &DashboardsService{}
+
+// DashboardsInterface is the interface implemented by DashboardsService.
+type DashboardsInterface interface {
+	Add(ctx context.Context, req types.AddDashboardRequest) (*types.Dashboard, error)
+	CloneTile(ctx context.Context, dashboardID string, tileID string, req types.CloneTileRequest) (*types.Tile, error)
+	Delete(ctx context.Context, dashboardID string) error
+	Get(ctx context.Context, dashboardID string) (*types.Dashboard, error)
+	GetTile(ctx context.Context, dashboardID string, tileID string) (*types.Tile, error)
+	List(ctx context.Context) ([]types.Dashboard, error)
+	ListTiles(ctx context.Context, dashboardID string) ([]types.Tile, error)
+}
+
 // Add adds a dashboard.
 func (s *DashboardsService) Add(ctx context.Context, req types.AddDashboardRequest) (*types.Dashboard, error) {
+	ctx = withOperation(ctx, "Dashboards.Add")
 	u := dashboardsBasePath
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -28,7 +42,8 @@ func (s *DashboardsService) Add(ctx context.Context, req types.AddDashboardReque
 
 // CloneTile clones the specified tile from My workspace.
 func (s *DashboardsService) CloneTile(ctx context.Context, dashboardID string, tileID string, req types.CloneTileRequest) (*types.Tile, error) {
-	u := fmt.Sprintf("%s/%s/tiles/%s/Clone", dashboardsBasePath, dashboardID, tileID)
+	ctx = withOperation(ctx, "Dashboards.CloneTile")
+	u := fmt.Sprintf("%s/%s/tiles/%s/Clone", dashboardsBasePath, url.PathEscape(dashboardID), url.PathEscape(tileID))
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
 		return nil, err
@@ -40,7 +55,8 @@ func (s *DashboardsService) CloneTile(ctx context.Context, dashboardID string, t
 
 // Delete deletes the specified dashboard from My workspace.
 func (s *DashboardsService) Delete(ctx context.Context, dashboardID string) error {
-	u := fmt.Sprintf("%s/dashboards/%s", dashboardsBasePath, url.PathEscape(dashboardID))
+	ctx = withOperation(ctx, "Dashboards.Delete")
+	u := fmt.Sprintf("%s/%s", dashboardsBasePath, url.PathEscape(dashboardID))
 	_, resp, err := s.client.doRequest(ctx, "DELETE", u, nil)
 	if err != nil {
 		return err
@@ -52,7 +68,8 @@ func (s *DashboardsService) Delete(ctx context.Context, dashboardID string) erro
 
 // Get gets the specified dashboard from My workspace.
 func (s *DashboardsService) Get(ctx context.Context, dashboardID string) (*types.Dashboard, error) {
-	u := fmt.Sprintf("%s/dashboards/%s", dashboardsBasePath, url.PathEscape(dashboardID))
+	ctx = withOperation(ctx, "Dashboards.Get")
+	u := fmt.Sprintf("%s/%s", dashboardsBasePath, url.PathEscape(dashboardID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -64,6 +81,7 @@ func (s *DashboardsService) Get(ctx context.Context, dashboardID string) (*types
 
 // List returns a list of dashboards from My workspace.
 func (s *DashboardsService) List(ctx context.Context) ([]types.Dashboard, error) {
+	ctx = withOperation(ctx, "Dashboards.List")
 	u := dashboardsBasePath
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -71,14 +89,15 @@ func (s *DashboardsService) List(ctx context.Context) ([]types.Dashboard, error)
 	}
 	defer resp.Body.Close()
 
-	var result []types.Dashboard
+	var result types.DashboardList
 	_, err = toObject(resp, &result)
-	return result, err
+	return result.Value, err
 }
 
 // GetTile returns the specified tile within the specified dashboard from My workspace.
 func (s *DashboardsService) GetTile(ctx context.Context, dashboardID string, tileID string) (*types.Tile, error) {
-	u := fmt.Sprintf("%s/dashboards/%s/tiles/%s", dashboardsBasePath, url.PathEscape(dashboardID), url.PathEscape(tileID))
+	ctx = withOperation(ctx, "Dashboards.GetTile")
+	u := fmt.Sprintf("%s/%s/tiles/%s", dashboardsBasePath, url.PathEscape(dashboardID), url.PathEscape(tileID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -90,14 +109,15 @@ func (s *DashboardsService) GetTile(ctx context.Context, dashboardID string, til
 
 // ListTiles returns a list of tiles within the specified dashboard from My workspace.
 func (s *DashboardsService) ListTiles(ctx context.Context, dashboardID string) ([]types.Tile, error) {
-	u := fmt.Sprintf("%s/dashboards/%s/tiles", dashboardsBasePath, url.PathEscape(dashboardID))
+	ctx = withOperation(ctx, "Dashboards.ListTiles")
+	u := fmt.Sprintf("%s/%s/tiles", dashboardsBasePath, url.PathEscape(dashboardID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var result []types.Tile
+	var result types.TileList
 	_, err = toObject(resp, &result)
-	return result, err
+	return result.Value, err
 }
@@ -0,0 +1,64 @@
+package powerbi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestDashboardsRoutes(t *testing.T) {
+	testRoutes(t, []routeTest{
+		{
+			pattern: "POST /v1.0/myorg/dashboards",
+			body:    `{"name":"Sales"}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Dashboards.Add(ctx, types.AddDashboardRequest{Name: "Sales"})
+				return err
+			},
+		},
+		{
+			pattern: "POST /v1.0/myorg/dashboards/d1/tiles/t1/Clone",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Dashboards.CloneTile(ctx, "d1", "t1", types.CloneTileRequest{TargetDashboardID: "d2"})
+				return err
+			},
+		},
+		{
+			pattern: "DELETE /v1.0/myorg/dashboards/d1",
+			call: func(ctx context.Context, c *Client) error {
+				return c.Dashboards.Delete(ctx, "d1")
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/dashboards/d1",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Dashboards.Get(ctx, "d1")
+				return err
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/dashboards",
+			response: `{"value":[{"id":"d1"},{"id":"d2"}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				dashboards, err := c.Dashboards.List(ctx)
+				return checkLen(dashboards, err, 2)
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/dashboards/d1/tiles/t1",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Dashboards.GetTile(ctx, "d1", "t1")
+				return err
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/dashboards/d1/tiles",
+			response: `{"value":[{"id":"t1"}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				tiles, err := c.Dashboards.ListTiles(ctx, "d1")
+				return checkLen(tiles, err, 1)
+			},
+		},
+	})
+}
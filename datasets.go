@@ -2,8 +2,13 @@ package powerbi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"net/http"
 	"net/url"
+	"path"
+	"strings"
 
 	"github.com/stpabhi/powerbi-go/types"
 )
@@ -15,7 +20,29 @@ type DatasetsService service
 var _ DatasetsInterface = &DatasetsService{}
 
 type DatasetsInterface interface {
+	BindToGateway(ctx context.Context, datasetID string, req types.BindToGatewayRequest) error
+	CancelRefresh(ctx context.Context, datasetID, refreshID string) error
+	Dataset(ctx context.Context, datasetID string) (*types.Dataset, error)
+	DatasetUsers(ctx context.Context, datasetID string) (*types.DatasetUsersAccess, error)
+	Datasets(ctx context.Context) (*types.DatasetList, error)
+	Datasources(ctx context.Context, datasetID string) (*types.DatasourceList, error)
+	DeleteDataset(ctx context.Context, datasetID string) error
+	DirectQueryRefreshSchedule(ctx context.Context, datasetID string) (*types.DirectQueryRefreshSchedule, error)
+	DiscoverGateways(ctx context.Context, datasetID string) (*types.GatewayList, error)
+	ExecuteQueries(ctx context.Context, datasetID string, req types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error)
+	GatewayDatasources(ctx context.Context, datasetID string) (*types.GatewayDatasourceList, error)
 	Group() DatasetGroup
+	Parameters(ctx context.Context, datasetID string) (*types.MashupParameterList, error)
+	RefreshDataset(ctx context.Context, datasetID string, req types.DatasetRefreshRequest) (string, error)
+	RefreshExecutionDetails(ctx context.Context, datasetID, refreshID string) (*types.DatasetRefreshDetail, error)
+	RefreshHistory(ctx context.Context, datasetID string, opts types.RefreshHistoryOptions) (*types.RefreshList, error)
+	RefreshSchedule(ctx context.Context, datasetID string) (*types.RefreshSchedule, error)
+	SetAllConnections(ctx context.Context, datasetID string, req types.SetAllConnectionsRequest) error
+	UpdateDatasources(ctx context.Context, datasetID string, req types.UpdateDatasourcesRequest) error
+	UpdateDirectQueryRefreshSchedule(ctx context.Context, datasetID string, schedule types.DirectQueryRefreshSchedule) error
+	UpdateParameters(ctx context.Context, datasetID string, req types.UpdateMashupParametersRequest) error
+	UpdateRefreshSchedule(ctx context.Context, datasetID string, schedule types.RefreshSchedule) error
+	WaitForRefresh(ctx context.Context, datasetID, refreshID string, opts WaitOptions) (*types.Refresh, error)
 }
 
 type DatasetGroup interface {
@@ -32,6 +59,18 @@ type DatasetGroup interface {
 	DirectQueryRefreshSchedule(ctx context.Context, groupID, datasetID string) (*types.DirectQueryRefreshSchedule, error)
 	GatewayDatasources(ctx context.Context, groupID, datasetID string) (*types.GatewayDatasourceList, error)
 	Parameters(ctx context.Context, groupID, datasetID string) (*types.MashupParameterList, error)
+	QueryRows(ctx context.Context, groupID, datasetID string, q WindowedQuery) iter.Seq2[map[string]any, error]
+	RefreshDataset(ctx context.Context, groupID, datasetID string, req types.DatasetRefreshRequest) (string, error)
+	RefreshExecutionDetails(ctx context.Context, groupID, datasetID, refreshID string) (*types.DatasetRefreshDetail, error)
+	RefreshHistory(ctx context.Context, groupID, datasetID string, opts types.RefreshHistoryOptions) (*types.RefreshList, error)
+	RefreshSchedule(ctx context.Context, groupID, datasetID string) (*types.RefreshSchedule, error)
+	SetAllConnections(ctx context.Context, groupID, datasetID string, req types.SetAllConnectionsRequest) error
+	TakeOver(ctx context.Context, groupID, datasetID string) error
+	UpdateDatasources(ctx context.Context, groupID, datasetID string, req types.UpdateDatasourcesRequest) error
+	UpdateDirectQueryRefreshSchedule(ctx context.Context, groupID, datasetID string, schedule types.DirectQueryRefreshSchedule) error
+	UpdateParameters(ctx context.Context, groupID, datasetID string, req types.UpdateMashupParametersRequest) error
+	UpdateRefreshSchedule(ctx context.Context, groupID, datasetID string, schedule types.RefreshSchedule) error
+	WaitForRefresh(ctx context.Context, groupID, datasetID, refreshID string, opts WaitOptions) (*types.Refresh, error)
 }
 
 type datasetGroupService service
@@ -43,8 +82,9 @@ func (s *DatasetsService) Group() DatasetGroup {
 // BindToGateway Binds the specified dataset from the specified workspace to the specified gateway, optionally with a given set of data source IDs.
 // If you don't supply a specific data source ID, the dataset will be bound to the first matching data source in the gateway.
 func (s *datasetGroupService) BindToGateway(ctx context.Context, groupID, datasetID string, req types.BindToGatewayRequest) error {
+	ctx = withOperation(ctx, "Datasets.Group.BindToGateway")
 	u := fmt.Sprintf("%s/%s/%s/%s/Default.BindToGateway", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return err
 	}
@@ -55,6 +95,7 @@ func (s *datasetGroupService) BindToGateway(ctx context.Context, groupID, datase
 
 // CancelRefresh cancels the specified refresh operation for the specified dataset from the specified workspace.
 func (s *datasetGroupService) CancelRefresh(ctx context.Context, groupID, datasetID, refreshID string) error {
+	ctx = withOperation(ctx, "Datasets.Group.CancelRefresh")
 	u := fmt.Sprintf("%s/%s/%s/%s/refreshes/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID), url.PathEscape(refreshID))
 	_, resp, err := s.client.doRequest(ctx, "DELETE", u, nil)
 	if err != nil {
@@ -67,6 +108,7 @@ func (s *datasetGroupService) CancelRefresh(ctx context.Context, groupID, datase
 
 // DeleteDataset deletes the specified dataset from the specified workspace.
 func (s *datasetGroupService) DeleteDataset(ctx context.Context, groupID, datasetID string) error {
+	ctx = withOperation(ctx, "Datasets.Group.DeleteDataset")
 	u := fmt.Sprintf("%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "DELETE", u, nil)
 	if err != nil {
@@ -79,6 +121,7 @@ func (s *datasetGroupService) DeleteDataset(ctx context.Context, groupID, datase
 
 // DiscoverGateways returns a list of gateways that the specified dataset from the specified workspace can be bound to.
 func (s *datasetGroupService) DiscoverGateways(ctx context.Context, groupID, datasetID string) (*types.GatewayList, error) {
+	ctx = withOperation(ctx, "Datasets.Group.DiscoverGateways")
 	u := fmt.Sprintf("%s/%s/%s/%s/Default.DiscoverGateways", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -95,20 +138,24 @@ func (s *datasetGroupService) DiscoverGateways(ctx context.Context, groupID, dat
 	return &result, nil
 }
 
-// ExecuteQueries executes Data Analysis Expressions (DAX) queries against the provided dataset.
+// ExecuteQueries executes Data Analysis Expressions (DAX) queries against the provided dataset. Failures reported in
+// the body of a successful response are returned as a *types.DAXQueryError alongside the response, unless
+// req.AllowPartialResults is set.
 func (s *datasetGroupService) ExecuteQueries(ctx context.Context, groupID, datasetID string, req types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error) {
+	ctx = withOperation(ctx, "Datasets.Group.ExecuteQueries")
 	u := fmt.Sprintf("%s/%s/%s/%s/executeQueries", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return toObject(resp, &types.DatasetExecuteQueriesResponse{})
+	return decodeExecuteQueries(resp, req)
 }
 
 // Dataset returns the specified dataset from the specified workspace.
 func (s *datasetGroupService) Dataset(ctx context.Context, groupID, datasetID string) (*types.Dataset, error) {
+	ctx = withOperation(ctx, "Datasets.Group.Dataset")
 	u := fmt.Sprintf("%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -121,6 +168,7 @@ func (s *datasetGroupService) Dataset(ctx context.Context, groupID, datasetID st
 
 // DatasetToDataflowLinks returns a list of upstream dataflows for datasets from the specified workspace.
 func (s *datasetGroupService) DatasetToDataflowLinks(ctx context.Context, groupID string) (*types.DatasetToDataflowLinksResponse, error) {
+	ctx = withOperation(ctx, "Datasets.Group.DatasetToDataflowLinks")
 	u := fmt.Sprintf("%s/%s/%s/upstreamDataflows", groupsBasePath, url.PathEscape(groupID), datasetsBasePath)
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -133,6 +181,7 @@ func (s *datasetGroupService) DatasetToDataflowLinks(ctx context.Context, groupI
 
 // DatasetUsers returns a list of principals that have access to the specified dataset.
 func (s *datasetGroupService) DatasetUsers(ctx context.Context, groupID, datasetID string) (*types.DatasetUsersAccess, error) {
+	ctx = withOperation(ctx, "Datasets.Group.DatasetUsers")
 	u := fmt.Sprintf("%s/%s/%s/%s/users", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -145,6 +194,7 @@ func (s *datasetGroupService) DatasetUsers(ctx context.Context, groupID, dataset
 
 // Datasets returns a list of datasets from the specified workspace.
 func (s *datasetGroupService) Datasets(ctx context.Context, groupID string) (*types.DatasetList, error) {
+	ctx = withOperation(ctx, "Datasets.Group.Datasets")
 	u := fmt.Sprintf("%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath)
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -157,6 +207,7 @@ func (s *datasetGroupService) Datasets(ctx context.Context, groupID string) (*ty
 
 // Datasources returns a list of data sources for the specified dataset from the specified workspace.
 func (s *datasetGroupService) Datasources(ctx context.Context, groupID, datasetID string) (*types.DatasourceList, error) {
+	ctx = withOperation(ctx, "Datasets.Group.Datasources")
 	u := fmt.Sprintf("%s/%s/%s/%s/datasources", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -169,6 +220,7 @@ func (s *datasetGroupService) Datasources(ctx context.Context, groupID, datasetI
 
 // DirectQueryRefreshSchedule returns the refresh schedule for a specified DirectQuery or LiveConnection dataset from the specified workspace.
 func (s *datasetGroupService) DirectQueryRefreshSchedule(ctx context.Context, groupID, datasetID string) (*types.DirectQueryRefreshSchedule, error) {
+	ctx = withOperation(ctx, "Datasets.Group.DirectQueryRefreshSchedule")
 	u := fmt.Sprintf("%s/%s/%s/%s/directQueryRefreshSchedule", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -181,6 +233,7 @@ func (s *datasetGroupService) DirectQueryRefreshSchedule(ctx context.Context, gr
 
 // GatewayDatasources returns a list of gateway data sources for the specified dataset from the specified workspace.
 func (s *datasetGroupService) GatewayDatasources(ctx context.Context, groupID, datasetID string) (*types.GatewayDatasourceList, error) {
+	ctx = withOperation(ctx, "Datasets.Group.GatewayDatasources")
 	u := fmt.Sprintf("%s/%s/%s/%s/Default.GetBoundGatewayDatasources", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -193,6 +246,7 @@ func (s *datasetGroupService) GatewayDatasources(ctx context.Context, groupID, d
 
 // Parameters returns a list of parameters for the specified dataset from the specified workspace.
 func (s *datasetGroupService) Parameters(ctx context.Context, groupID, datasetID string) (*types.MashupParameterList, error) {
+	ctx = withOperation(ctx, "Datasets.Group.Parameters")
 	u := fmt.Sprintf("%s/%s/%s/%s/parameters", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
 	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
 	if err != nil {
@@ -202,3 +256,578 @@ func (s *datasetGroupService) Parameters(ctx context.Context, groupID, datasetID
 
 	return toObject(resp, &types.MashupParameterList{})
 }
+
+// RefreshDataset triggers a refresh for the specified dataset from the specified workspace and returns the ID of the refresh,
+// which CancelRefresh accepts. Any field of req other than NotifyOption makes it an enhanced refresh.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/refresh-dataset-in-group
+func (s *datasetGroupService) RefreshDataset(ctx context.Context, groupID, datasetID string, req types.DatasetRefreshRequest) (string, error) {
+	ctx = withOperation(ctx, "Datasets.Group.RefreshDataset")
+	u := fmt.Sprintf("%s/%s/%s/%s/refreshes", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	return s.client.refreshDataset(ctx, u, req)
+}
+
+// RefreshExecutionDetails returns the execution details of the specified enhanced refresh for the specified dataset
+// from the specified workspace.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-execution-details-in-group
+func (s *datasetGroupService) RefreshExecutionDetails(ctx context.Context, groupID, datasetID, refreshID string) (*types.DatasetRefreshDetail, error) {
+	ctx = withOperation(ctx, "Datasets.Group.RefreshExecutionDetails")
+	u := fmt.Sprintf("%s/%s/%s/%s/refreshes/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID), url.PathEscape(refreshID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.DatasetRefreshDetail{})
+}
+
+// RefreshHistory returns the refresh history for the specified dataset from the specified workspace, most recent first.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-history-in-group
+func (s *datasetGroupService) RefreshHistory(ctx context.Context, groupID, datasetID string, opts types.RefreshHistoryOptions) (*types.RefreshList, error) {
+	ctx = withOperation(ctx, "Datasets.Group.RefreshHistory")
+	u := fmt.Sprintf("%s/%s/%s/%s/refreshes", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, err
+	}
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.RefreshList{})
+}
+
+// RefreshSchedule returns the refresh schedule for the specified dataset from the specified workspace.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-schedule-in-group
+func (s *datasetGroupService) RefreshSchedule(ctx context.Context, groupID, datasetID string) (*types.RefreshSchedule, error) {
+	ctx = withOperation(ctx, "Datasets.Group.RefreshSchedule")
+	u := fmt.Sprintf("%s/%s/%s/%s/refreshSchedule", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.RefreshSchedule{})
+}
+
+// SetAllConnections sets the connection string of all the connections of the specified dataset from the specified
+// workspace. It only supports datasets with a single DirectQuery data source created with enhanced dataset metadata.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/set-all-dataset-connections-in-group
+func (s *datasetGroupService) SetAllConnections(ctx context.Context, groupID, datasetID string, req types.SetAllConnectionsRequest) error {
+	ctx = withOperation(ctx, "Datasets.Group.SetAllConnections")
+	if req.ConnectionString == "" {
+		return errors.New("powerbi: connection string is empty")
+	}
+	u := fmt.Sprintf("%s/%s/%s/%s/Default.SetAllConnections", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// TakeOver transfers ownership of the specified dataset from the specified workspace to the current user.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/take-over-in-group
+func (s *datasetGroupService) TakeOver(ctx context.Context, groupID, datasetID string) error {
+	ctx = withOperation(ctx, "Datasets.Group.TakeOver")
+	u := fmt.Sprintf("%s/%s/%s/%s/Default.TakeOver", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(withIdempotent(ctx), "POST", u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateDatasources updates the connection details of the data sources of the specified dataset from the specified
+// workspace. Each update selects a data source by its current type and connection details. The request is validated
+// before it is sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-datasources-in-group
+func (s *datasetGroupService) UpdateDatasources(ctx context.Context, groupID, datasetID string, req types.UpdateDatasourcesRequest) error {
+	ctx = withOperation(ctx, "Datasets.Group.UpdateDatasources")
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/%s/%s/Default.UpdateDatasources", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateDirectQueryRefreshSchedule updates the refresh schedule for the specified DirectQuery or LiveConnection dataset
+// from the specified workspace. The schedule is validated before it is sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-direct-query-refresh-schedule-in-group
+func (s *datasetGroupService) UpdateDirectQueryRefreshSchedule(ctx context.Context, groupID, datasetID string, schedule types.DirectQueryRefreshSchedule) error {
+	ctx = withOperation(ctx, "Datasets.Group.UpdateDirectQueryRefreshSchedule")
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/%s/%s/directQueryRefreshSchedule", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.patchJSON(ctx, u, types.DirectQueryRefreshScheduleRequest{Value: schedule})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateParameters updates the parameters of the specified dataset from the specified workspace. The updates are
+// validated against the current parameters of the dataset, fetched with Parameters, before they are sent: each
+// parameter must exist and be updated once, and its new value must match its type.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-parameters-in-group
+func (s *datasetGroupService) UpdateParameters(ctx context.Context, groupID, datasetID string, req types.UpdateMashupParametersRequest) error {
+	params, err := s.Parameters(ctx, groupID, datasetID)
+	if err != nil {
+		return err
+	}
+	if err := types.ValidateParameterUpdates(params.Value, req.UpdateDetails); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+
+	ctx = withOperation(ctx, "Datasets.Group.UpdateParameters")
+	u := fmt.Sprintf("%s/%s/%s/%s/Default.UpdateParameters", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateRefreshSchedule updates the refresh schedule for the specified dataset from the specified workspace. Empty
+// fields of schedule are left unchanged. The schedule is validated before it is sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-refresh-schedule-in-group
+func (s *datasetGroupService) UpdateRefreshSchedule(ctx context.Context, groupID, datasetID string, schedule types.RefreshSchedule) error {
+	ctx = withOperation(ctx, "Datasets.Group.UpdateRefreshSchedule")
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/%s/%s/refreshSchedule", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.patchJSON(ctx, u, types.RefreshScheduleRequest{Value: schedule})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// refreshHistoryWindow is the number of most recent refreshes WaitForRefresh
+// looks for the awaited refresh in.
+const refreshHistoryWindow = 30
+
+// WaitForRefresh polls the refresh history of the specified dataset from the specified workspace until the refresh
+// with the given ID, as returned by RefreshDataset, ends. It returns the refresh history entry of the refresh, and a
+// *types.RefreshError carrying its service exception if it ends in a status other than Completed. A refresh that
+// does not show up in the history is waited for until opts.Timeout elapses or ctx is done.
+func (s *datasetGroupService) WaitForRefresh(ctx context.Context, groupID, datasetID, refreshID string, opts WaitOptions) (*types.Refresh, error) {
+	ctx = withOperation(ctx, "Datasets.Group.WaitForRefresh")
+	u := fmt.Sprintf("%s/%s/%s/%s/refreshes", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
+	return s.client.waitForRefresh(ctx, u, refreshID, opts)
+}
+
+// BindToGateway binds the specified dataset from My workspace to the specified gateway, optionally with a given set of
+// data source IDs.
+func (s *DatasetsService) BindToGateway(ctx context.Context, datasetID string, req types.BindToGatewayRequest) error {
+	ctx = withOperation(ctx, "Datasets.BindToGateway")
+	u := fmt.Sprintf("%s/%s/Default.BindToGateway", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// CancelRefresh cancels the specified refresh operation for the specified dataset from My workspace.
+func (s *DatasetsService) CancelRefresh(ctx context.Context, datasetID, refreshID string) error {
+	ctx = withOperation(ctx, "Datasets.CancelRefresh")
+	u := fmt.Sprintf("%s/%s/refreshes/%s", datasetsBasePath, url.PathEscape(datasetID), url.PathEscape(refreshID))
+	_, resp, err := s.client.doRequest(ctx, "DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// Dataset returns the specified dataset from My workspace.
+func (s *DatasetsService) Dataset(ctx context.Context, datasetID string) (*types.Dataset, error) {
+	ctx = withOperation(ctx, "Datasets.Dataset")
+	u := fmt.Sprintf("%s/%s", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.Dataset{})
+}
+
+// DatasetUsers returns a list of principals that have access to the specified dataset from My workspace.
+func (s *DatasetsService) DatasetUsers(ctx context.Context, datasetID string) (*types.DatasetUsersAccess, error) {
+	ctx = withOperation(ctx, "Datasets.DatasetUsers")
+	u := fmt.Sprintf("%s/%s/users", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.DatasetUsersAccess{})
+}
+
+// Datasets returns a list of datasets from My workspace.
+func (s *DatasetsService) Datasets(ctx context.Context) (*types.DatasetList, error) {
+	ctx = withOperation(ctx, "Datasets.Datasets")
+	u := datasetsBasePath
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.DatasetList{})
+}
+
+// Datasources returns a list of data sources for the specified dataset from My workspace.
+func (s *DatasetsService) Datasources(ctx context.Context, datasetID string) (*types.DatasourceList, error) {
+	ctx = withOperation(ctx, "Datasets.Datasources")
+	u := fmt.Sprintf("%s/%s/datasources", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.DatasourceList{})
+}
+
+// DeleteDataset deletes the specified dataset from My workspace.
+func (s *DatasetsService) DeleteDataset(ctx context.Context, datasetID string) error {
+	ctx = withOperation(ctx, "Datasets.DeleteDataset")
+	u := fmt.Sprintf("%s/%s", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// DirectQueryRefreshSchedule returns the refresh schedule for a specified DirectQuery or LiveConnection dataset from
+// My workspace.
+func (s *DatasetsService) DirectQueryRefreshSchedule(ctx context.Context, datasetID string) (*types.DirectQueryRefreshSchedule, error) {
+	ctx = withOperation(ctx, "Datasets.DirectQueryRefreshSchedule")
+	u := fmt.Sprintf("%s/%s/directQueryRefreshSchedule", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.DirectQueryRefreshSchedule{})
+}
+
+// DiscoverGateways returns a list of gateways that the specified dataset from My workspace can be bound to.
+func (s *DatasetsService) DiscoverGateways(ctx context.Context, datasetID string) (*types.GatewayList, error) {
+	ctx = withOperation(ctx, "Datasets.DiscoverGateways")
+	u := fmt.Sprintf("%s/%s/Default.DiscoverGateways", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.GatewayList{})
+}
+
+// ExecuteQueries executes Data Analysis Expressions (DAX) queries against the specified dataset from My workspace.
+// Failures reported in the body of a successful response are handled like in the group variant.
+func (s *DatasetsService) ExecuteQueries(ctx context.Context, datasetID string, req types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error) {
+	ctx = withOperation(ctx, "Datasets.ExecuteQueries")
+	u := fmt.Sprintf("%s/%s/executeQueries", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodeExecuteQueries(resp, req)
+}
+
+// GatewayDatasources returns a list of gateway data sources for the specified dataset from My workspace.
+func (s *DatasetsService) GatewayDatasources(ctx context.Context, datasetID string) (*types.GatewayDatasourceList, error) {
+	ctx = withOperation(ctx, "Datasets.GatewayDatasources")
+	u := fmt.Sprintf("%s/%s/Default.GetBoundGatewayDatasources", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.GatewayDatasourceList{})
+}
+
+// Parameters returns a list of parameters for the specified dataset from My workspace.
+func (s *DatasetsService) Parameters(ctx context.Context, datasetID string) (*types.MashupParameterList, error) {
+	ctx = withOperation(ctx, "Datasets.Parameters")
+	u := fmt.Sprintf("%s/%s/parameters", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.MashupParameterList{})
+}
+
+// RefreshDataset triggers a refresh for the specified dataset from My workspace and returns the ID of the refresh.
+// Any field of req other than NotifyOption makes it an enhanced refresh.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/refresh-dataset
+func (s *DatasetsService) RefreshDataset(ctx context.Context, datasetID string, req types.DatasetRefreshRequest) (string, error) {
+	ctx = withOperation(ctx, "Datasets.RefreshDataset")
+	u := fmt.Sprintf("%s/%s/refreshes", datasetsBasePath, url.PathEscape(datasetID))
+	return s.client.refreshDataset(ctx, u, req)
+}
+
+// RefreshExecutionDetails returns the execution details of the specified enhanced refresh for the specified dataset
+// from My workspace.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-execution-details
+func (s *DatasetsService) RefreshExecutionDetails(ctx context.Context, datasetID, refreshID string) (*types.DatasetRefreshDetail, error) {
+	ctx = withOperation(ctx, "Datasets.RefreshExecutionDetails")
+	u := fmt.Sprintf("%s/%s/refreshes/%s", datasetsBasePath, url.PathEscape(datasetID), url.PathEscape(refreshID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.DatasetRefreshDetail{})
+}
+
+// RefreshHistory returns the refresh history for the specified dataset from My workspace, most recent first.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-history
+func (s *DatasetsService) RefreshHistory(ctx context.Context, datasetID string, opts types.RefreshHistoryOptions) (*types.RefreshList, error) {
+	ctx = withOperation(ctx, "Datasets.RefreshHistory")
+	u := fmt.Sprintf("%s/%s/refreshes", datasetsBasePath, url.PathEscape(datasetID))
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, err
+	}
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.RefreshList{})
+}
+
+// RefreshSchedule returns the refresh schedule for the specified dataset from My workspace.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-schedule
+func (s *DatasetsService) RefreshSchedule(ctx context.Context, datasetID string) (*types.RefreshSchedule, error) {
+	ctx = withOperation(ctx, "Datasets.RefreshSchedule")
+	u := fmt.Sprintf("%s/%s/refreshSchedule", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.RefreshSchedule{})
+}
+
+// SetAllConnections sets the connection string of all the connections of the specified dataset from My workspace. It
+// only supports datasets with a single DirectQuery data source created with enhanced dataset metadata.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/set-all-dataset-connections
+func (s *DatasetsService) SetAllConnections(ctx context.Context, datasetID string, req types.SetAllConnectionsRequest) error {
+	ctx = withOperation(ctx, "Datasets.SetAllConnections")
+	if req.ConnectionString == "" {
+		return errors.New("powerbi: connection string is empty")
+	}
+	u := fmt.Sprintf("%s/%s/Default.SetAllConnections", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateDatasources updates the connection details of the data sources of the specified dataset from My workspace.
+// The request is validated before it is sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-datasources
+func (s *DatasetsService) UpdateDatasources(ctx context.Context, datasetID string, req types.UpdateDatasourcesRequest) error {
+	ctx = withOperation(ctx, "Datasets.UpdateDatasources")
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/Default.UpdateDatasources", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateDirectQueryRefreshSchedule updates the refresh schedule for the specified DirectQuery or LiveConnection dataset
+// from My workspace. The schedule is validated before it is sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-direct-query-refresh-schedule
+func (s *DatasetsService) UpdateDirectQueryRefreshSchedule(ctx context.Context, datasetID string, schedule types.DirectQueryRefreshSchedule) error {
+	ctx = withOperation(ctx, "Datasets.UpdateDirectQueryRefreshSchedule")
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/directQueryRefreshSchedule", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.patchJSON(ctx, u, types.DirectQueryRefreshScheduleRequest{Value: schedule})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateParameters updates the parameters of the specified dataset from My workspace. Like the group variant, it
+// validates the updates against the current parameters of the dataset before they are sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-parameters
+func (s *DatasetsService) UpdateParameters(ctx context.Context, datasetID string, req types.UpdateMashupParametersRequest) error {
+	params, err := s.Parameters(ctx, datasetID)
+	if err != nil {
+		return err
+	}
+	if err := types.ValidateParameterUpdates(params.Value, req.UpdateDetails); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+
+	ctx = withOperation(ctx, "Datasets.UpdateParameters")
+	u := fmt.Sprintf("%s/%s/Default.UpdateParameters", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateRefreshSchedule updates the refresh schedule for the specified dataset from My workspace. Empty fields of
+// schedule are left unchanged. The schedule is validated before it is sent.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-refresh-schedule
+func (s *DatasetsService) UpdateRefreshSchedule(ctx context.Context, datasetID string, schedule types.RefreshSchedule) error {
+	ctx = withOperation(ctx, "Datasets.UpdateRefreshSchedule")
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("powerbi: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/refreshSchedule", datasetsBasePath, url.PathEscape(datasetID))
+	_, resp, err := s.client.patchJSON(ctx, u, types.RefreshScheduleRequest{Value: schedule})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// WaitForRefresh polls the refresh history of the specified dataset from My workspace until the refresh with the
+// given ID, as returned by RefreshDataset, ends. It behaves like the group variant.
+func (s *DatasetsService) WaitForRefresh(ctx context.Context, datasetID, refreshID string, opts WaitOptions) (*types.Refresh, error) {
+	ctx = withOperation(ctx, "Datasets.WaitForRefresh")
+	u := fmt.Sprintf("%s/%s/refreshes", datasetsBasePath, url.PathEscape(datasetID))
+	return s.client.waitForRefresh(ctx, u, refreshID, opts)
+}
+
+// refreshDataset posts a refresh request to u and returns the refresh ID,
+// the last segment of the Location header of enhanced refreshes. Power BI
+// only sets the request ID header for other refreshes, which is the ID their
+// refresh history reports.
+func (c *Client) refreshDataset(ctx context.Context, u string, req types.DatasetRefreshRequest) (string, error) {
+	_, resp, err := c.postJSON(ctx, u, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if location := resp.Header.Get("Location"); location != "" {
+		if l, err := url.Parse(location); err == nil {
+			if id := path.Base(l.Path); id != "refreshes" && id != "." && id != "/" {
+				return id, nil
+			}
+		}
+	}
+	return RequestID(resp.Header), nil
+}
+
+// waitForRefresh polls the refresh history at u until the refresh with the
+// given request ID ends. Poll requests are named after the operation of ctx.
+func (c *Client) waitForRefresh(ctx context.Context, u, refreshID string, opts WaitOptions) (*types.Refresh, error) {
+	u, err := addOptions(u, types.RefreshHistoryOptions{Top: refreshHistoryWindow})
+	if err != nil {
+		return nil, err
+	}
+
+	var refresh *types.Refresh
+	p, err := NewPoller(c, u, PollerOptions[types.RefreshList]{
+		Done: func(history *types.RefreshList) (bool, error) {
+			for i := range history.Value {
+				r := &history.Value[i]
+				if !strings.EqualFold(r.RequestID, refreshID) {
+					continue
+				}
+				refresh = r
+				switch {
+				case !r.Status.Terminal():
+					return false, nil
+				case r.Status != types.RefreshStatusCompleted:
+					return true, types.NewRefreshError(r)
+				}
+				return true, nil
+			}
+			return false, nil
+		},
+		Interval:    opts.Interval,
+		MaxInterval: opts.MaxInterval,
+		Timeout:     opts.Timeout,
+		Operation:   Operation(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.PollUntilDone(ctx)
+	return refresh, err
+}
+
+// decodeExecuteQueries decodes an ExecuteQueries response and returns, unless
+// req allows partial results, the first failure it reports as an error.
+func decodeExecuteQueries(resp *http.Response, req types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error) {
+	result, err := toObject(resp, &types.DatasetExecuteQueriesResponse{})
+	if err != nil {
+		return nil, err
+	}
+	if req.AllowPartialResults {
+		return result, nil
+	}
+	if daxErr := types.NewDAXQueryError(req, result); daxErr != nil {
+		return result, daxErr
+	}
+	return result, nil
+}
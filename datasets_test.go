@@ -0,0 +1,463 @@
+package powerbi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestUpdateDirectQueryRefreshScheduleBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule types.DirectQueryRefreshSchedule
+		want     string
+	}{
+		{
+			name:     "frequency",
+			schedule: types.DirectQueryRefreshSchedule{Frequency: 30, LocalTimeZoneID: "UTC"},
+			want:     `{"value":{"frequency":30,"localTimeZoneId":"UTC"}}`,
+		},
+		{
+			name:     "days and times",
+			schedule: types.DirectQueryRefreshSchedule{Days: []types.Day{types.DayMonday}, Times: []string{"07:00", "19:30"}},
+			want:     `{"value":{"days":["Monday"],"times":["07:00","19:30"]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux := setup(t)
+			var bodies []string
+			record := func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+			}
+			mux.HandleFunc("PATCH /v1.0/myorg/datasets/d1/directQueryRefreshSchedule", record)
+			mux.HandleFunc("PATCH /v1.0/myorg/groups/g1/datasets/d1/directQueryRefreshSchedule", record)
+
+			ctx := context.Background()
+			if err := c.Datasets.UpdateDirectQueryRefreshSchedule(ctx, "d1", tt.schedule); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Datasets.Group().UpdateDirectQueryRefreshSchedule(ctx, "g1", "d1", tt.schedule); err != nil {
+				t.Fatal(err)
+			}
+			for _, body := range bodies {
+				if body != tt.want {
+					t.Errorf("body = %s, want %s", body, tt.want)
+				}
+			}
+			if len(bodies) != 2 {
+				t.Errorf("%d requests, want 2", len(bodies))
+			}
+		})
+	}
+}
+
+func TestDatasetsRoutes(t *testing.T) {
+	testRoutes(t, []routeTest{
+		{
+			pattern: "POST /v1.0/myorg/datasets/d1/Default.BindToGateway",
+			body:    `{"datasourceObjectIds":["s1"],"gatewayObjectId":"gw1"}`,
+			call: func(ctx context.Context, c *Client) error {
+				return c.Datasets.BindToGateway(ctx, "d1", types.BindToGatewayRequest{DatasourceObjectIDs: []string{"s1"}, GatewayObjectID: "gw1"})
+			},
+		},
+		{
+			pattern: "DELETE /v1.0/myorg/datasets/d1/refreshes/r1",
+			call: func(ctx context.Context, c *Client) error {
+				return c.Datasets.CancelRefresh(ctx, "d1", "r1")
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/datasets/d1",
+			response: `{"id":"d1","name":"Sales"}`,
+			call: func(ctx context.Context, c *Client) error {
+				ds, err := c.Datasets.Dataset(ctx, "d1")
+				if err == nil && ds.Name != "Sales" {
+					err = fmt.Errorf("name = %q, want Sales", ds.Name)
+				}
+				return err
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/datasets/d1/users",
+			response: `{"value":[{"identifier":"a@contoso.com"}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				users, err := c.Datasets.DatasetUsers(ctx, "d1")
+				if err != nil {
+					return err
+				}
+				return checkLen(users.Value, nil, 1)
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/datasets",
+			response: `{"value":[{"id":"d1"},{"id":"d2"}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				list, err := c.Datasets.Datasets(ctx)
+				if err != nil {
+					return err
+				}
+				return checkLen(list.Value, nil, 2)
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/datasets/d1/datasources",
+			response: `{"value":[{"datasourceId":"s1"}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				list, err := c.Datasets.Datasources(ctx, "d1")
+				if err != nil {
+					return err
+				}
+				return checkLen(list.Value, nil, 1)
+			},
+		},
+		{
+			pattern: "DELETE /v1.0/myorg/datasets/d1",
+			call: func(ctx context.Context, c *Client) error {
+				return c.Datasets.DeleteDataset(ctx, "d1")
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/directQueryRefreshSchedule",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.DirectQueryRefreshSchedule(ctx, "d1")
+				return err
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/Default.DiscoverGateways",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.DiscoverGateways(ctx, "d1")
+				return err
+			},
+		},
+		{
+			pattern: "POST /v1.0/myorg/datasets/d1/executeQueries",
+			body:    `{"impersonatedUserName":"","queries":[{"query":"EVALUATE 'Sales'"}],"serializerSettings":{"includeNulls":true}}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.ExecuteQueries(ctx, "d1", types.DatasetExecuteQueriesRequest{
+					Queries:            []types.DatasetExecuteQueriesQuery{{Query: "EVALUATE 'Sales'"}},
+					SerializerSettings: types.DatasetExecuteQueriesSerializationSettings{IncludeNulls: true},
+				})
+				return err
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/Default.GetBoundGatewayDatasources",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.GatewayDatasources(ctx, "d1")
+				return err
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/parameters",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.Parameters(ctx, "d1")
+				return err
+			},
+		},
+		{
+			pattern: "POST /v1.0/myorg/datasets/d1/refreshes",
+			body:    `{"notifyOption":"NoNotification","type":"Full"}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.RefreshDataset(ctx, "d1", types.DatasetRefreshRequest{
+					NotifyOption: types.NotifyOptionNoNotification,
+					Type:         types.DatasetRefreshTypeFull,
+				})
+				return err
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/refreshes/r1",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.RefreshExecutionDetails(ctx, "d1", "r1")
+				return err
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/refreshes",
+			query:   "%24top=5",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.RefreshHistory(ctx, "d1", types.RefreshHistoryOptions{Top: 5})
+				return err
+			},
+		},
+		{
+			pattern: "GET /v1.0/myorg/datasets/d1/refreshSchedule",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.RefreshSchedule(ctx, "d1")
+				return err
+			},
+		},
+		{
+			pattern: "POST /v1.0/myorg/datasets/d1/Default.SetAllConnections",
+			body:    `{"connectionString":"Data Source=sql;Initial Catalog=sales"}`,
+			call: func(ctx context.Context, c *Client) error {
+				return c.Datasets.SetAllConnections(ctx, "d1", types.SetAllConnectionsRequest{ConnectionString: "Data Source=sql;Initial Catalog=sales"})
+			},
+		},
+		{
+			pattern: "POST /v1.0/myorg/datasets/d1/Default.UpdateDatasources",
+			body: `{"updateDetails":[{"connectionDetails":{"database":"sales2","server":"sql"},` +
+				`"datasourceSelector":{"datasourceType":"Sql","connectionDetails":{"database":"sales","server":"sql"}}}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				return c.Datasets.UpdateDatasources(ctx, "d1", types.UpdateDatasourcesRequest{
+					UpdateDetails: []types.UpdateDatasourceConnectionRequest{{
+						ConnectionDetails: &types.DatasourceConnectionDetails{Server: "sql", Database: "sales2"},
+						DatasourceSelector: types.Datasource{
+							DatasourceType:    "Sql",
+							ConnectionDetails: &types.DatasourceConnectionDetails{Server: "sql", Database: "sales"},
+						},
+					}},
+				})
+			},
+		},
+		{
+			pattern: "PATCH /v1.0/myorg/datasets/d1/refreshSchedule",
+			body:    `{"value":{"days":["Monday"],"localTimeZoneId":"UTC","times":["07:00"]}}`,
+			call: func(ctx context.Context, c *Client) error {
+				return c.Datasets.UpdateRefreshSchedule(ctx, "d1", types.RefreshSchedule{
+					Days:            []types.Day{types.DayMonday},
+					LocalTimeZoneID: "UTC",
+					Times:           []string{"07:00"},
+				})
+			},
+		},
+		{
+			pattern:  "GET /v1.0/myorg/datasets/d1/refreshes",
+			query:    "%24top=30",
+			response: `{"value":[{"requestId":"r1","status":"Completed"}]}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Datasets.WaitForRefresh(ctx, "d1", "r1", WaitOptions{})
+				return err
+			},
+		},
+	})
+}
+
+func TestDatasetsUpdateParameters(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/datasets/d1/parameters", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"value":[{"name":"Server","type":"Text","isRequired":true},{"name":"Days","type":"Number"}]}`)
+	})
+	var body string
+	mux.HandleFunc("POST /v1.0/myorg/datasets/d1/Default.UpdateParameters", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	})
+
+	ctx := context.Background()
+	err := c.Datasets.UpdateParameters(ctx, "d1", types.UpdateMashupParametersRequest{
+		UpdateDetails: []types.UpdateMashupParameterDetails{{Name: "Days", NewValue: "7"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"updateDetails":[{"name":"Days","newValue":"7"}]}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+
+	body = ""
+	err = c.Datasets.UpdateParameters(ctx, "d1", types.UpdateMashupParametersRequest{
+		UpdateDetails: []types.UpdateMashupParameterDetails{{Name: "Days", NewValue: "seven"}},
+	})
+	if err == nil {
+		t.Error("UpdateParameters() with an invalid value succeeded")
+	}
+	if body != "" {
+		t.Errorf("UpdateParameters() with an invalid value sent %s", body)
+	}
+}
+
+func TestRefreshDatasetID(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     string
+	}{
+		{"enhanced", "https://api.powerbi.com/v1.0/myorg/groups/g1/datasets/d1/refreshes/e1", "e1"},
+		{"trailing slash", "https://api.powerbi.com/v1.0/myorg/datasets/d1/refreshes/e1/", "e1"},
+		{"query string", "https://api.powerbi.com/v1.0/myorg/datasets/d1/refreshes/e1?api-version=2", "e1"},
+		{"relative", "/v1.0/myorg/datasets/d1/refreshes/e1", "e1"},
+		{"no location", "", "req1"},
+		{"collection", "https://api.powerbi.com/v1.0/myorg/datasets/d1/refreshes", "req1"},
+		{"root", "/", "req1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux := setup(t)
+			mux.HandleFunc("POST /v1.0/myorg/datasets/d1/refreshes", func(w http.ResponseWriter, r *http.Request) {
+				if tt.location != "" {
+					w.Header().Set("Location", tt.location)
+				}
+				w.Header().Set("RequestId", "req1")
+				w.WriteHeader(http.StatusAccepted)
+			})
+			id, err := c.Datasets.RefreshDataset(context.Background(), "d1", types.DatasetRefreshRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.want {
+				t.Errorf("RefreshDataset() = %q, want %q", id, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshDatasetMSRequestID(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("POST /v1.0/myorg/groups/g1/datasets/d1/refreshes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-request-id", "req2")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	id, err := c.Datasets.Group().RefreshDataset(context.Background(), "g1", "d1", types.DatasetRefreshRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "req2" {
+		t.Errorf("RefreshDataset() = %q, want req2", id)
+	}
+}
+
+// serveRefreshHistory serves the refresh history of dataset d1 of group g1,
+// answering the i-th poll with the i-th history, or the last one once they
+// run out. It returns the number of polls served so far.
+func serveRefreshHistory(t *testing.T, mux *http.ServeMux, histories ...string) func() int {
+	t.Helper()
+	var polls atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/groups/g1/datasets/d1/refreshes", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("$top"); got != "30" {
+			t.Errorf("$top = %q, want 30", got)
+		}
+		i := int(polls.Add(1)) - 1
+		io.WriteString(w, histories[min(i, len(histories)-1)])
+	})
+	return func() int { return int(polls.Load()) }
+}
+
+func TestWaitForRefresh(t *testing.T) {
+	c, mux := setup(t)
+	polls := serveRefreshHistory(t, mux,
+		`{"value":[{"requestId":"r1","status":"Unknown"},{"requestId":"r0","status":"Failed"}]}`,
+		`{"value":[{"requestId":"r2","status":"Unknown"},{"requestId":"R1","status":"Unknown"}]}`,
+		`{"value":[{"requestId":"r2","status":"Unknown"},{"id":7,"requestId":"r1","status":"Completed"}]}`,
+	)
+
+	refresh, err := c.Datasets.Group().WaitForRefresh(context.Background(), "g1", "d1", "r1", WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refresh.ID != 7 || refresh.Status != types.RefreshStatusCompleted {
+		t.Errorf("WaitForRefresh() = %+v, want refresh 7 completed", refresh)
+	}
+	if got := polls(); got != 3 {
+		t.Errorf("%d polls, want 3", got)
+	}
+}
+
+func TestWaitForRefreshError(t *testing.T) {
+	tests := []struct {
+		status    types.RefreshStatus
+		exception string
+		wantCode  string
+		wantMsg   string
+	}{
+		{
+			status:    types.RefreshStatusFailed,
+			exception: `{"errorCode":"ModelRefreshFailed_CredentialsNotSpecified","errorDescription":"Credentials are missing."}`,
+			wantCode:  "ModelRefreshFailed_CredentialsNotSpecified",
+			wantMsg:   "refresh r1 ended with status Failed: ModelRefreshFailed_CredentialsNotSpecified: Credentials are missing.",
+		},
+		{
+			status:    types.RefreshStatusFailed,
+			exception: `not json`,
+			wantMsg:   "refresh r1 ended with status Failed: not json",
+		},
+		{
+			status:  types.RefreshStatusCancelled,
+			wantMsg: "refresh r1 ended with status Cancelled",
+		},
+		{
+			status:  types.RefreshStatusDisabled,
+			wantMsg: "refresh r1 ended with status Disabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantMsg, func(t *testing.T) {
+			c, mux := setup(t)
+			entry, _ := json.Marshal(types.Refresh{RequestID: "r1", Status: tt.status, ServiceExceptionJSON: tt.exception})
+			serveRefreshHistory(t, mux, `{"value":[`+string(entry)+`]}`)
+
+			refresh, err := c.Datasets.Group().WaitForRefresh(context.Background(), "g1", "d1", "r1", WaitOptions{Interval: time.Millisecond})
+			var refreshErr *types.RefreshError
+			if !errors.As(err, &refreshErr) {
+				t.Fatalf("WaitForRefresh() error = %v, want a *types.RefreshError", err)
+			}
+			if got := refreshErr.Error(); got != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", got, tt.wantMsg)
+			}
+			if refreshErr.Status != tt.status || refreshErr.Refresh != refresh {
+				t.Errorf("RefreshError = %+v, want status %s and the returned refresh", refreshErr, tt.status)
+			}
+			var code string
+			if refreshErr.ServiceException != nil {
+				code = refreshErr.ServiceException.ErrorCode
+			}
+			if code != tt.wantCode {
+				t.Errorf("service exception code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWaitForRefreshNotInHistory(t *testing.T) {
+	c, mux := setup(t)
+	polls := serveRefreshHistory(t, mux, `{"value":[{"requestId":"r2","status":"Completed"}]}`)
+
+	refresh, err := c.Datasets.Group().WaitForRefresh(context.Background(), "g1", "d1", "r1", WaitOptions{
+		Interval: time.Millisecond,
+		Timeout:  50 * time.Millisecond,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForRefresh() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if refresh != nil {
+		t.Errorf("WaitForRefresh() = %+v, want nil", refresh)
+	}
+	if polls() < 2 {
+		t.Errorf("%d polls, want several", polls())
+	}
+}
+
+func TestWaitForRefreshCanceled(t *testing.T) {
+	c, mux := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var polls atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/datasets/d1/refreshes", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) == 2 {
+			cancel()
+		}
+		io.WriteString(w, `{"value":[{"requestId":"r1","status":"Unknown"}]}`)
+	})
+
+	refresh, err := c.Datasets.WaitForRefresh(ctx, "d1", "r1", WaitOptions{Interval: time.Millisecond})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForRefresh() error = %v, want %v", err, context.Canceled)
+	}
+	if refresh == nil || refresh.Status != types.RefreshStatusUnknown {
+		t.Errorf("WaitForRefresh() = %+v, want the last refresh polled", refresh)
+	}
+	if got := polls.Load(); got != 2 {
+		t.Errorf("%d polls, want 2", got)
+	}
+}
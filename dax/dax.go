@@ -0,0 +1,325 @@
+// Package dax builds Data Analysis Expressions (DAX) queries for
+// ExecuteQueries, escaping table and column names and string literals:
+//
+//	q := dax.NewQuery().
+//		DefineMeasure("Sales", "Total", dax.Func("SUM", dax.Column("Sales", "Amount"))).
+//		Evaluate(
+//			dax.SummarizeColumns(dax.Column("Geography", "Region")).
+//				Filter(dax.TreatAs(dax.List("EU", "US"), dax.Column("Geography", "Region"))).
+//				Column("Total", dax.Measure("Total")),
+//			dax.Desc(dax.Measure("Total")),
+//		)
+//	query, err := q.Build()
+//
+// Expressions render as DAX with their String method. Invalid expressions,
+// such as a column without a name, render as best they can and are reported
+// by Query.Build.
+package dax
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expr is a DAX expression, scalar or table.
+type Expr interface {
+	// String returns the DAX of the expression.
+	String() string
+
+	write(w *writer)
+}
+
+// writer renders expressions and records the first error found in them.
+type writer struct {
+	strings.Builder
+	err error
+}
+
+func (w *writer) fail(format string, args ...any) {
+	if w.err == nil {
+		w.err = fmt.Errorf("dax: "+format, args...)
+	}
+}
+
+func (w *writer) list(exprs []Expr) {
+	for i, e := range exprs {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.expr(e)
+	}
+}
+
+func (w *writer) expr(e Expr) {
+	if e == nil {
+		w.fail("nil expression")
+		w.WriteString("BLANK()")
+		return
+	}
+	e.write(w)
+}
+
+// render renders e and returns its DAX and first error.
+func render(e Expr) (string, error) {
+	var w writer
+	w.expr(e)
+	return w.String(), w.err
+}
+
+func stringOf(e Expr) string {
+	s, _ := render(e)
+	return s
+}
+
+// raw is DAX written verbatim.
+type raw string
+
+// Raw returns an expression of verbatim DAX, for constructs the package does
+// not cover. It is not escaped.
+func Raw(dax string) Expr {
+	return raw(dax)
+}
+
+func (r raw) String() string  { return string(r) }
+func (r raw) write(w *writer) { w.WriteString(string(r)) }
+
+// QuoteTable returns name as a quoted table name, such as 'Sales Order'.
+func QuoteTable(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// QuoteName returns name as a bracketed column or measure name, such as
+// [Total Sales].
+func QuoteName(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+// QuoteString returns s as a string literal, such as "O""Brien".
+func QuoteString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+type tableRef struct {
+	name string
+}
+
+// Table returns a reference to the table of the given name.
+func Table(name string) Expr {
+	return tableRef{name: name}
+}
+
+func (t tableRef) String() string { return stringOf(t) }
+
+func (t tableRef) write(w *writer) {
+	if t.name == "" {
+		w.fail("table name is empty")
+	}
+	w.WriteString(QuoteTable(t.name))
+}
+
+type columnRef struct {
+	table, name string
+}
+
+// Column returns a reference to the column of the given table and name, such
+// as 'Sales'[Amount]. An empty table refers to a column by name only, as for
+// the columns of a table expression.
+func Column(table, name string) Expr {
+	return columnRef{table: table, name: name}
+}
+
+// Measure returns a reference to the measure of the given name, such as
+// [Total Sales].
+func Measure(name string) Expr {
+	return columnRef{name: name}
+}
+
+func (c columnRef) String() string { return stringOf(c) }
+
+func (c columnRef) write(w *writer) {
+	if c.name == "" {
+		w.fail("column or measure name is empty")
+	}
+	if c.table != "" {
+		w.WriteString(QuoteTable(c.table))
+	}
+	w.WriteString(QuoteName(c.name))
+}
+
+// ParseColumn returns a reference to the column or measure of the given name,
+// as named in the rows of ExecuteQueries results, such as "Sales[Amount]",
+// "'Sales Order'[Amount]" or "[Total Sales]". Names without brackets are
+// reported by Query.Build.
+func ParseColumn(name string) Expr {
+	open := strings.IndexByte(name, '[')
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return invalidColumn(name)
+	}
+	table := strings.TrimSpace(name[:open])
+	if len(table) >= 2 && table[0] == '\'' && table[len(table)-1] == '\'' {
+		table = strings.ReplaceAll(table[1:len(table)-1], "''", "'")
+	}
+	return Column(table, strings.ReplaceAll(name[open+1:len(name)-1], "]]", "]"))
+}
+
+type invalidColumn string
+
+func (c invalidColumn) String() string { return string(c) }
+
+func (c invalidColumn) write(w *writer) {
+	w.fail("invalid column name %q, want Table[Column] or [Measure]", string(c))
+	w.WriteString(string(c))
+}
+
+// identifier matches the names of variables.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type varRef string
+
+// Var returns a reference to the variable of the given name, as defined by
+// Query.DefineVar.
+func Var(name string) Expr {
+	return varRef(name)
+}
+
+func (v varRef) String() string { return string(v) }
+
+func (v varRef) write(w *writer) {
+	if !identifier.MatchString(string(v)) {
+		w.fail("invalid variable name %q", string(v))
+	}
+	w.WriteString(string(v))
+}
+
+// String returns a string literal.
+func String(s string) Expr {
+	return raw(QuoteString(s))
+}
+
+// Int returns an integer literal.
+func Int(i int64) Expr {
+	return raw(strconv.FormatInt(i, 10))
+}
+
+// Float returns a decimal number literal. NaN and infinities render as the
+// divisions by zero that produce them.
+func Float(f float64) Expr {
+	switch {
+	case math.IsNaN(f):
+		return raw("(0 / 0)")
+	case math.IsInf(f, 1):
+		return raw("(1 / 0)")
+	case math.IsInf(f, -1):
+		return raw("(-1 / 0)")
+	}
+	return raw(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Bool returns TRUE() or FALSE().
+func Bool(b bool) Expr {
+	if b {
+		return raw("TRUE()")
+	}
+	return raw("FALSE()")
+}
+
+// Blank returns BLANK().
+func Blank() Expr {
+	return raw("BLANK()")
+}
+
+// Date returns the date of t, as DATE(y, m, d).
+func Date(t time.Time) Expr {
+	return raw(fmt.Sprintf("DATE(%d, %d, %d)", t.Year(), t.Month(), t.Day()))
+}
+
+// DateTime returns the date and time of t, to the millisecond, as
+// DATE(y, m, d) + TIME(h, m, s), plus the milliseconds as a fraction of a day
+// if any.
+func DateTime(t time.Time) Expr {
+	s := fmt.Sprintf("DATE(%d, %d, %d) + TIME(%d, %d, %d)", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
+	if ms := t.Nanosecond() / int(time.Millisecond); ms > 0 {
+		s += fmt.Sprintf(" + %d / 86400000", ms)
+	}
+	return raw("(" + s + ")")
+}
+
+type invalid struct {
+	value any
+}
+
+func (i invalid) String() string { return "BLANK()" }
+
+func (i invalid) write(w *writer) {
+	w.fail("unsupported value %v (%T)", i.value, i.value)
+	w.WriteString("BLANK()")
+}
+
+// Value returns the literal of a Go value: a string, a boolean, an integer, a
+// floating-point number or a time.Time, rendered as a date if it is exactly
+// midnight and as a date time otherwise. A nil value is BLANK(), and
+// expressions are returned as is. Values of other types, and unsigned integers
+// above math.MaxInt64, are reported by Query.Build.
+func Value(v any) Expr {
+	switch x := v.(type) {
+	case nil:
+		return Blank()
+	case Expr:
+		return x
+	case string:
+		return String(x)
+	case bool:
+		return Bool(x)
+	case int:
+		return Int(int64(x))
+	case int8:
+		return Int(int64(x))
+	case int16:
+		return Int(int64(x))
+	case int32:
+		return Int(int64(x))
+	case int64:
+		return Int(x)
+	case uint:
+		return uintValue(uint64(x), v)
+	case uint8:
+		return Int(int64(x))
+	case uint16:
+		return Int(int64(x))
+	case uint32:
+		return Int(int64(x))
+	case uint64:
+		return uintValue(x, v)
+	case float32:
+		return Float(float64(x))
+	case float64:
+		return Float(x)
+	case time.Time:
+		if x.Hour() == 0 && x.Minute() == 0 && x.Second() == 0 && x.Nanosecond() == 0 {
+			return Date(x)
+		}
+		return DateTime(x)
+	}
+	return invalid{value: v}
+}
+
+// uintValue returns the integer literal of u, or an invalid value if u
+// overflows the 64-bit signed integers of DAX.
+func uintValue(u uint64, v any) Expr {
+	if u > math.MaxInt64 {
+		return invalid{value: v}
+	}
+	return Int(int64(u))
+}
+
+func valuesOf(vs []any) []Expr {
+	exprs := make([]Expr, len(vs))
+	for i, v := range vs {
+		exprs[i] = Value(v)
+	}
+	return exprs
+}
@@ -0,0 +1,132 @@
+package dax
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{QuoteTable("Sales"), `'Sales'`},
+		{QuoteTable("Sales Order"), `'Sales Order'`},
+		{QuoteTable("O'Brien's"), `'O''Brien''s'`},
+		{QuoteTable("'"), `''''`},
+		{QuoteName("Total Sales"), `[Total Sales]`},
+		{QuoteName("a]b"), `[a]]b]`},
+		{QuoteName("[x]"), `[[x]]]`},
+		{QuoteString(`O"Brien`), `"O""Brien"`},
+		{QuoteString(`""`), `""""""`},
+		{QuoteString("it's\n"), "\"it's\n\""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s, want %s", tt.got, tt.want)
+		}
+	}
+}
+
+func TestExprString(t *testing.T) {
+	tests := []struct {
+		expr Expr
+		want string
+	}{
+		{Table("Sales Order"), `'Sales Order'`},
+		{Column("Sales", "Amount]"), `'Sales'[Amount]]]`},
+		{Column("", "Region"), `[Region]`},
+		{Measure("Total"), `[Total]`},
+		{ParseColumn("Sales[Amount]"), `'Sales'[Amount]`},
+		{ParseColumn("'O''Brien'[a]]b]"), `'O''Brien'[a]]b]`},
+		{ParseColumn("[Total Sales]"), `[Total Sales]`},
+		{String(`say "hi"`), `"say ""hi"""`},
+		{Value(`'); EVALUATE x //`), `"'); EVALUATE x //"`},
+		{Value(nil), `BLANK()`},
+		{Value(true), `TRUE()`},
+		{Value(int8(-3)), `-3`},
+		{Value(uint64(math.MaxInt64)), `9223372036854775807`},
+		{Value(uint(42)), `42`},
+		{Value(1.5), `1.5`},
+		{Value(math.Inf(-1)), `(-1 / 0)`},
+		{Value(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)), `DATE(2024, 1, 31)`},
+		{Value(time.Date(2024, 1, 31, 13, 4, 5, 6e6, time.UTC)), `(DATE(2024, 1, 31) + TIME(13, 4, 5) + 6 / 86400000)`},
+		{Value(time.Date(2024, 1, 31, 0, 0, 0, 250e6, time.UTC)), `(DATE(2024, 1, 31) + TIME(0, 0, 0) + 250 / 86400000)`},
+		{In(Column("Geography", "Region"), "EU", `U"S`), `'Geography'[Region] IN {"EU", "U""S"}`},
+		{And(Eq(Column("T", "A"), 1), Ne(Column("T", "B"), "x")), `('T'[A] = 1 && 'T'[B] <> "x")`},
+		{Or(Gt(Var("n"), 2)), `n > 2`},
+	}
+	for _, tt := range tests {
+		if got := tt.expr.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestQueryBuild(t *testing.T) {
+	q := NewQuery().
+		DefineMeasure("Sales", "Total", Func("SUM", Column("Sales", "Amount"))).
+		DefineVar("_regions", List("EU", "US")).
+		Evaluate(
+			SummarizeColumns(Column("Geography", "Region")).
+				Filter(TreatAs(Var("_regions"), Column("Geography", "Region"))).
+				Column("Total", Measure("Total")),
+			Desc(Measure("Total")),
+		)
+	got, err := q.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "DEFINE\n" +
+		"\tMEASURE 'Sales'[Total] = SUM('Sales'[Amount])\n" +
+		"\tVAR _regions = {\"EU\", \"US\"}\n" +
+		"EVALUATE\n" +
+		"\tSUMMARIZECOLUMNS('Geography'[Region], TREATAS(_regions, 'Geography'[Region]), \"Total\", [Total])\n" +
+		"ORDER BY\n" +
+		"\t[Total] DESC"
+	if got != want {
+		t.Errorf("Build() = %q, want %q", got, want)
+	}
+}
+
+func TestQueryBuildErrors(t *testing.T) {
+	tests := []struct {
+		query   *Query
+		wantErr string
+	}{
+		{NewQuery(), "no EVALUATE"},
+		{NewQuery().Evaluate(nil), "nil expression"},
+		{NewQuery().Evaluate(Table("")), "table name is empty"},
+		{NewQuery().Evaluate(Filter(Table("T"), Eq(Column("T", ""), 1))), "column or measure name is empty"},
+		{NewQuery().Evaluate(Filter(Table("T"), Eq(ParseColumn("Amount"), 1))), `invalid column name "Amount"`},
+		{NewQuery().Evaluate(Filter(Table("T"), Eq(Column("T", "A"), struct{}{}))), "unsupported value"},
+		{NewQuery().Evaluate(Filter(Table("T"), Eq(Column("T", "A"), uint64(math.MaxInt64+1)))), "unsupported value 9223372036854775808 (uint64)"},
+		{NewQuery().Evaluate(Filter(Table("T"), Eq(Column("T", "A"), uint(math.MaxUint)))), "unsupported value 18446744073709551615 (uint)"},
+		{NewQuery().DefineMeasure("", "Total", Int(1)).Evaluate(Table("T")), `measure "Total" has no table`},
+		{NewQuery().DefineVar("x y", Int(1)).Evaluate(Table("T")), `invalid variable name "x y"`},
+		{NewQuery().DefineVar("1x", Int(1)).Evaluate(Table("T")), `invalid variable name "1x"`},
+		{NewQuery().Evaluate(Var("x; EVALUATE y")), "invalid variable name"},
+		{NewQuery().Evaluate(Func("SUM(x)", Int(1))), "invalid function name"},
+		{NewQuery().Evaluate(SummarizeColumns()), "SUMMARIZECOLUMNS has no arguments"},
+		{NewQuery().Evaluate(SummarizeColumns().Column("", Int(1))), "SUMMARIZECOLUMNS column name is empty"},
+		{NewQuery().Evaluate(Filter(Table("T"), And())), "has no operands"},
+	}
+	for _, tt := range tests {
+		got, err := tt.query.Build()
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Build() of %q: error %v, want error containing %q", tt.query, err, tt.wantErr)
+		}
+		if got != "" {
+			t.Errorf("Build() of %q = %q, want empty DAX on error", tt.query, got)
+		}
+	}
+}
+
+func TestFuncNames(t *testing.T) {
+	for _, name := range []string{"SUM", "DATE", "PATHITEM", "ISONORAFTER", "NORM.DIST", "_f1"} {
+		if _, err := NewQuery().Evaluate(Func(name)).Build(); err != nil {
+			t.Errorf("Func(%q): %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package dax
+
+import "strings"
+
+type call struct {
+	name string
+	args []Expr
+}
+
+// Func returns a call of the DAX function of the given name, such as
+// Func("SUM", Column("Sales", "Amount")).
+func Func(name string, args ...Expr) Expr {
+	return call{name: name, args: args}
+}
+
+func (c call) String() string { return stringOf(c) }
+
+func (c call) write(w *writer) {
+	if !identifier.MatchString(strings.ReplaceAll(c.name, ".", "_")) {
+		w.fail("invalid function name %q", c.name)
+	}
+	w.WriteString(c.name)
+	w.WriteByte('(')
+	w.list(c.args)
+	w.WriteByte(')')
+}
+
+// CalculateTable returns CALCULATETABLE(table, filters...).
+func CalculateTable(table Expr, filters ...Expr) Expr {
+	return Func("CALCULATETABLE", append([]Expr{table}, filters...)...)
+}
+
+// Filter returns FILTER(table, condition).
+func Filter(table, condition Expr) Expr {
+	return Func("FILTER", table, condition)
+}
+
+// TreatAs returns TREATAS(table, columns...), which applies the values of
+// table as filters on columns.
+func TreatAs(table Expr, columns ...Expr) Expr {
+	return Func("TREATAS", append([]Expr{table}, columns...)...)
+}
+
+// TopN returns TOPN(n, table, orderBy...), the first n rows of table in the
+// given order.
+func TopN(n int, table Expr, orderBy ...Order) Expr {
+	args := []Expr{Int(int64(n)), table}
+	for _, o := range orderBy {
+		args = append(args, o.Expr, raw(o.direction()))
+	}
+	return Func("TOPN", args...)
+}
+
+type list struct {
+	values []Expr
+}
+
+// List returns a single-column table constructor of values, such as
+// {"EU", "US"}, converted as by Value.
+func List(values ...any) Expr {
+	return list{values: valuesOf(values)}
+}
+
+func (l list) String() string { return stringOf(l) }
+
+func (l list) write(w *writer) {
+	w.WriteByte('{')
+	w.list(l.values)
+	w.WriteByte('}')
+}
+
+// namedColumn is a column added to a table by name.
+type namedColumn struct {
+	name string
+	expr Expr
+}
+
+// SummarizeColumnsExpr is a SUMMARIZECOLUMNS call, as returned by
+// SummarizeColumns.
+type SummarizeColumnsExpr struct {
+	groupBy []Expr
+	filters []Expr
+	columns []namedColumn
+}
+
+// SummarizeColumns returns SUMMARIZECOLUMNS grouping by the given columns.
+// Filter and Column add filter tables and named columns to it.
+func SummarizeColumns(groupBy ...Expr) *SummarizeColumnsExpr {
+	return &SummarizeColumnsExpr{groupBy: groupBy}
+}
+
+// Filter adds filter tables, such as TreatAs or Filter calls.
+func (s *SummarizeColumnsExpr) Filter(tables ...Expr) *SummarizeColumnsExpr {
+	s.filters = append(s.filters, tables...)
+	return s
+}
+
+// Column adds a column of the given name computed by expr.
+func (s *SummarizeColumnsExpr) Column(name string, expr Expr) *SummarizeColumnsExpr {
+	s.columns = append(s.columns, namedColumn{name: name, expr: expr})
+	return s
+}
+
+func (s *SummarizeColumnsExpr) String() string { return stringOf(s) }
+
+func (s *SummarizeColumnsExpr) write(w *writer) {
+	args := append(append([]Expr{}, s.groupBy...), s.filters...)
+	for _, c := range s.columns {
+		if c.name == "" {
+			w.fail("SUMMARIZECOLUMNS column name is empty")
+		}
+		args = append(args, String(c.name), c.expr)
+	}
+	if len(args) == 0 {
+		w.fail("SUMMARIZECOLUMNS has no arguments")
+	}
+	Func("SUMMARIZECOLUMNS", args...).write(w)
+}
+
+type binary struct {
+	op          string
+	left, right Expr
+}
+
+func (b binary) String() string { return stringOf(b) }
+
+func (b binary) write(w *writer) {
+	w.expr(b.left)
+	w.WriteString(" " + b.op + " ")
+	w.expr(b.right)
+}
+
+// Eq returns left = right. Operands that are not expressions are converted as
+// by Value.
+func Eq(left, right any) Expr { return binary{op: "=", left: Value(left), right: Value(right)} }
+
+// Ne returns left <> right.
+func Ne(left, right any) Expr { return binary{op: "<>", left: Value(left), right: Value(right)} }
+
+// Lt returns left < right.
+func Lt(left, right any) Expr { return binary{op: "<", left: Value(left), right: Value(right)} }
+
+// Le returns left <= right.
+func Le(left, right any) Expr { return binary{op: "<=", left: Value(left), right: Value(right)} }
+
+// Gt returns left > right.
+func Gt(left, right any) Expr { return binary{op: ">", left: Value(left), right: Value(right)} }
+
+// Ge returns left >= right.
+func Ge(left, right any) Expr { return binary{op: ">=", left: Value(left), right: Value(right)} }
+
+// In returns expr IN {values...}.
+func In(expr Expr, values ...any) Expr {
+	return binary{op: "IN", left: expr, right: List(values...)}
+}
+
+type logical struct {
+	op    string
+	exprs []Expr
+}
+
+// And returns the conjunction of exprs, parenthesized if there are several.
+func And(exprs ...Expr) Expr {
+	return logical{op: "&&", exprs: exprs}
+}
+
+// Or returns the disjunction of exprs, parenthesized if there are several.
+func Or(exprs ...Expr) Expr {
+	return logical{op: "||", exprs: exprs}
+}
+
+func (l logical) String() string { return stringOf(l) }
+
+func (l logical) write(w *writer) {
+	switch len(l.exprs) {
+	case 0:
+		w.fail("%s has no operands", l.op)
+	case 1:
+		w.expr(l.exprs[0])
+		return
+	}
+	w.WriteByte('(')
+	for i, e := range l.exprs {
+		if i > 0 {
+			w.WriteString(" " + l.op + " ")
+		}
+		w.expr(e)
+	}
+	w.WriteByte(')')
+}
+
+// Not returns NOT(expr).
+func Not(expr Expr) Expr {
+	return Func("NOT", expr)
+}
@@ -0,0 +1,128 @@
+package dax
+
+// Order is an ORDER BY or TOPN sort key.
+type Order struct {
+	Expr       Expr
+	Descending bool
+}
+
+// Asc sorts by expr in ascending order.
+func Asc(expr Expr) Order {
+	return Order{Expr: expr}
+}
+
+// Desc sorts by expr in descending order.
+func Desc(expr Expr) Order {
+	return Order{Expr: expr, Descending: true}
+}
+
+func (o Order) direction() string {
+	if o.Descending {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// definition is a MEASURE or VAR definition of a query.
+type definition struct {
+	measure bool
+	table   string
+	name    string
+	expr    Expr
+}
+
+type evaluation struct {
+	table   Expr
+	orderBy []Order
+}
+
+// Query is a DAX query: definitions of measures and variables followed by one
+// or more EVALUATE statements. The zero Query is empty and ready to use.
+type Query struct {
+	defs  []definition
+	evals []evaluation
+}
+
+// NewQuery returns an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// DefineMeasure defines a measure of the given table, which the query can
+// refer to with Measure(name).
+func (q *Query) DefineMeasure(table, name string, expr Expr) *Query {
+	q.defs = append(q.defs, definition{measure: true, table: table, name: name, expr: expr})
+	return q
+}
+
+// DefineVar defines a variable, which the query can refer to with Var(name).
+func (q *Query) DefineVar(name string, expr Expr) *Query {
+	q.defs = append(q.defs, definition{name: name, expr: expr})
+	return q
+}
+
+// Evaluate adds an EVALUATE statement returning table, sorted by orderBy.
+func (q *Query) Evaluate(table Expr, orderBy ...Order) *Query {
+	q.evals = append(q.evals, evaluation{table: table, orderBy: orderBy})
+	return q
+}
+
+// Build returns the DAX of the query, ready for ExecuteQueries, or the first
+// error found in it.
+func (q *Query) Build() (string, error) {
+	var w writer
+	q.write(&w)
+	if w.err != nil {
+		return "", w.err
+	}
+	return w.String(), nil
+}
+
+// String returns the DAX of the query, even if it is invalid.
+func (q *Query) String() string {
+	var w writer
+	q.write(&w)
+	return w.String()
+}
+
+func (q *Query) write(w *writer) {
+	if len(q.evals) == 0 {
+		w.fail("query has no EVALUATE statement")
+	}
+	if len(q.defs) > 0 {
+		w.WriteString("DEFINE\n")
+	}
+	for _, d := range q.defs {
+		if d.measure {
+			if d.table == "" {
+				w.fail("measure %q has no table", d.name)
+			}
+			w.WriteString("\tMEASURE ")
+			w.expr(Column(d.table, d.name))
+		} else {
+			w.WriteString("\tVAR ")
+			w.expr(Var(d.name))
+		}
+		w.WriteString(" = ")
+		w.expr(d.expr)
+		w.WriteByte('\n')
+	}
+	for i, e := range q.evals {
+		if i > 0 {
+			w.WriteByte('\n')
+		}
+		w.WriteString("EVALUATE\n\t")
+		w.expr(e.table)
+		if len(e.orderBy) == 0 {
+			continue
+		}
+		w.WriteString("\nORDER BY\n\t")
+		for j, o := range e.orderBy {
+			if j > 0 {
+				w.WriteString(", ")
+			}
+			w.expr(o.Expr)
+			w.WriteString(" " + o.direction())
+		}
+	}
+}
@@ -12,11 +12,24 @@ import (
 // https://learn.microsoft.com/en-us/rest/api/power-bi/embed-token
 type EmbedTokenService service
 
+var _ EmbedTokenInterface = &EmbedTokenService{}
+
+// EmbedTokenInterface is the interface implemented by EmbedTokenService.
+type EmbedTokenInterface interface {
+	GenerateToken(ctx context.Context, req types.GenerateTokenRequestV2) (*types.EmbedToken, error)
+	GenerateTokenForDashboardsInGroup(ctx context.Context, groupID, dashboardID string, req types.GenerateTokenRequest) (*types.EmbedToken, error)
+	GenerateTokenForDatasetsInGroup(ctx context.Context, groupID, datasetID string, req types.GenerateTokenRequest) (*types.EmbedToken, error)
+	GenerateTokenForReportsCreateInGroup(ctx context.Context, groupID string, req types.GenerateTokenRequest) (*types.EmbedToken, error)
+	GenerateTokenForReportsInGroup(ctx context.Context, groupID, reportID string, req types.GenerateTokenRequest) (*types.EmbedToken, error)
+	GenerateTokenForTilesInGroup(ctx context.Context, groupID, dashboardID, tileID string, req types.GenerateTokenRequest) (*types.EmbedToken, error)
+}
+
 // GenerateToken generates an embed token for multiple reports, datasets, and target workspaces.
 // POST /GenerateToken
 func (s *EmbedTokenService) GenerateToken(ctx context.Context, req types.GenerateTokenRequestV2) (*types.EmbedToken, error) {
+	ctx = withOperation(ctx, "EmbedToken.GenerateToken")
 	u := "GenerateToken"
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +41,9 @@ func (s *EmbedTokenService) GenerateToken(ctx context.Context, req types.Generat
 // GenerateTokenForDashboardsInGroup generates an embed token for a dashboard in a workspace.
 // POST /groups/{groupId}/dashboards/{dashboardId}/GenerateToken
 func (s *EmbedTokenService) GenerateTokenForDashboardsInGroup(ctx context.Context, groupID, dashboardID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	ctx = withOperation(ctx, "EmbedToken.GenerateTokenForDashboardsInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/GenerateToken", groupsBasePath, url.PathEscape(groupID), dashboardsBasePath, url.PathEscape(dashboardID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -41,8 +55,9 @@ func (s *EmbedTokenService) GenerateTokenForDashboardsInGroup(ctx context.Contex
 // GenerateTokenForDatasetsInGroup generates an embed token based on the specified dataset from the specified workspace.
 // POST /groups/{groupId}/datasets/{datasetId}/GenerateToken
 func (s *EmbedTokenService) GenerateTokenForDatasetsInGroup(ctx context.Context, groupID, datasetID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	ctx = withOperation(ctx, "EmbedToken.GenerateTokenForDatasetsInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/GenerateToken", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +69,9 @@ func (s *EmbedTokenService) GenerateTokenForDatasetsInGroup(ctx context.Context,
 // GenerateTokenForReportsCreateInGroup generates an embed token to allow report creation in the specified workspace based on the specified dataset.
 // POST /groups/{groupId}/reports/GenerateToken
 func (s *EmbedTokenService) GenerateTokenForReportsCreateInGroup(ctx context.Context, groupID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	ctx = withOperation(ctx, "EmbedToken.GenerateTokenForReportsCreateInGroup")
 	u := fmt.Sprintf("%s/%s/%s/GenerateToken", groupsBasePath, url.PathEscape(groupID), reportsBasePath)
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +83,9 @@ func (s *EmbedTokenService) GenerateTokenForReportsCreateInGroup(ctx context.Con
 // GenerateTokenForReportsInGroup generates an embed token to view or edit the specified report from the specified workspace.
 // POST /groups/{groupId}/reports/{reportId}/GenerateToken
 func (s *EmbedTokenService) GenerateTokenForReportsInGroup(ctx context.Context, groupID, reportID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	ctx = withOperation(ctx, "EmbedToken.GenerateTokenForReportsInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/GenerateToken", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +97,9 @@ func (s *EmbedTokenService) GenerateTokenForReportsInGroup(ctx context.Context,
 // GenerateTokenForTilesInGroup generates an embed token to view the specified tile from the specified workspace.
 // POST /groups/{groupId}/dashboards/{dashboardId}/tiles/{tileId}/GenerateToken
 func (s *EmbedTokenService) GenerateTokenForTilesInGroup(ctx context.Context, groupID, dashboardID, tileID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	ctx = withOperation(ctx, "EmbedToken.GenerateTokenForTilesInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/tiles/%s/GenerateToken", groupsBasePath, url.PathEscape(groupID), dashboardsBasePath, url.PathEscape(dashboardID), url.PathEscape(tileID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package powerbi
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -15,8 +16,33 @@ const groupsBasePath = "groups"
 // https://learn.microsoft.com/en-us/rest/api/power-bi/groups
 type GroupsService service
 
+var _ GroupsInterface = &GroupsService{}
+
+// GroupsInterface is the interface implemented by GroupsService.
+type GroupsInterface interface {
+	AddDashboard(ctx context.Context, groupID string, req types.AddDashboardRequest) (*types.Dashboard, error)
+	AddGroupUser(ctx context.Context, groupID string, user types.GroupUser) error
+	All(ctx context.Context, opts types.ListGroupsOptions) iter.Seq2[types.Group, error]
+	AllGroupUsers(ctx context.Context, groupID string, opts types.ListGroupUserOptions) iter.Seq2[types.GroupUser, error]
+	CloneTile(ctx context.Context, groupID, dashboardID, tileID string, req types.CloneTileRequest) (*types.Tile, error)
+	Create(ctx context.Context, req types.CreateGroupRequest, opts types.CreateGroupOptions) (*types.Group, error)
+	Delete(ctx context.Context, groupID string) error
+	DeleteDashboard(ctx context.Context, groupID, dashboardID string) error
+	DeleteGroupUser(ctx context.Context, groupID, user string, opts types.DeleteGroupUserOptions) error
+	Get(ctx context.Context, groupID string) (*types.Group, error)
+	GetDashboard(ctx context.Context, groupID, dashboardID string) (*types.Dashboard, error)
+	GetTile(ctx context.Context, groupID, dashboardID, tileID string) (*types.Tile, error)
+	List(ctx context.Context, opts types.ListGroupsOptions) ([]types.Group, error)
+	ListDashboards(ctx context.Context, groupID string) ([]types.Dashboard, error)
+	ListGroupUsers(ctx context.Context, groupID string, opts types.ListGroupUserOptions) ([]types.GroupUser, error)
+	ListTiles(ctx context.Context, groupID, dashboardID string) ([]types.Tile, error)
+	Update(ctx context.Context, groupID string, req types.UpdateGroupRequest) (*types.Group, error)
+	UpdateGroupUser(ctx context.Context, groupID string, user types.GroupUser) error
+}
+
 // Create creates a new workspace.
 func (s *GroupsService) Create(ctx context.Context, req types.CreateGroupRequest, opts types.CreateGroupOptions) (*types.Group, error) {
+	ctx = withOperation(ctx, "Groups.Create")
 	u := groupsBasePath
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -34,6 +60,7 @@ func (s *GroupsService) Create(ctx context.Context, req types.CreateGroupRequest
 
 // Delete deletes a workspace by ID.
 func (s *GroupsService) Delete(ctx context.Context, groupID string) error {
+	ctx = withOperation(ctx, "Groups.Delete")
 	u := fmt.Sprintf("%s/%s", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
@@ -46,6 +73,7 @@ func (s *GroupsService) Delete(ctx context.Context, groupID string) error {
 
 // Get returns a group (workspace) by ID.
 func (s *GroupsService) Get(ctx context.Context, groupID string) (*types.Group, error) {
+	ctx = withOperation(ctx, "Groups.Get")
 	u := fmt.Sprintf("%s/%s", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -58,6 +86,7 @@ func (s *GroupsService) Get(ctx context.Context, groupID string) (*types.Group,
 
 // List lists workspaces with optional $top, $skip and $filter parameters.
 func (s *GroupsService) List(ctx context.Context, opts types.ListGroupsOptions) ([]types.Group, error) {
+	ctx = withOperation(ctx, "Groups.List")
 	u := groupsBasePath
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -76,8 +105,22 @@ func (s *GroupsService) List(ctx context.Context, opts types.ListGroupsOptions)
 	return result.Value, err
 }
 
+// All returns an iterator over all workspaces matching opts, fetching them
+// page by page. opts.Top sets the page size and opts.Skip the initial offset.
+func (s *GroupsService) All(ctx context.Context, opts types.ListGroupsOptions) iter.Seq2[types.Group, error] {
+	if opts.Top <= 0 {
+		opts.Top = defaultPageSize
+	}
+	return offsetPager(ctx, opts.Top, opts.Skip, func(ctx context.Context, top, skip int) ([]types.Group, error) {
+		opts := opts
+		opts.Top, opts.Skip = top, skip
+		return s.List(ctx, opts)
+	})
+}
+
 // Update updates mutable fields of a workspace.
 func (s *GroupsService) Update(ctx context.Context, groupID string, req types.UpdateGroupRequest) (*types.Group, error) {
+	ctx = withOperation(ctx, "Groups.Update")
 	u := fmt.Sprintf("%s/%s", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.patchJSON(ctx, u, req)
 	if err != nil {
@@ -90,6 +133,7 @@ func (s *GroupsService) Update(ctx context.Context, groupID string, req types.Up
 
 // AddGroupUser grants access to a workspace.
 func (s *GroupsService) AddGroupUser(ctx context.Context, groupID string, user types.GroupUser) error {
+	ctx = withOperation(ctx, "Groups.AddGroupUser")
 	u := fmt.Sprintf("%s/%s/users", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.postJSON(ctx, u, user)
 	if err != nil {
@@ -103,6 +147,7 @@ func (s *GroupsService) AddGroupUser(ctx context.Context, groupID string, user t
 // DeleteGroupUser removes a user's access from a workspace.
 // The user parameter may be an email address or object ID.
 func (s *GroupsService) DeleteGroupUser(ctx context.Context, groupID, user string, opts types.DeleteGroupUserOptions) error {
+	ctx = withOperation(ctx, "Groups.DeleteGroupUser")
 	u := fmt.Sprintf("%s/%s/users/%s", groupsBasePath, url.PathEscape(groupID), url.PathEscape(user))
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -120,6 +165,7 @@ func (s *GroupsService) DeleteGroupUser(ctx context.Context, groupID, user strin
 
 // ListGroupUsers lists the users of a workspace.
 func (s *GroupsService) ListGroupUsers(ctx context.Context, groupID string, opts types.ListGroupUserOptions) ([]types.GroupUser, error) {
+	ctx = withOperation(ctx, "Groups.ListGroupUsers")
 	u := fmt.Sprintf("%s/%s/users", groupsBasePath, url.PathEscape(groupID))
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -138,8 +184,22 @@ func (s *GroupsService) ListGroupUsers(ctx context.Context, groupID string, opts
 	return result.Value, err
 }
 
+// AllGroupUsers returns an iterator over all users of a workspace, fetching
+// them page by page. opts.Top sets the page size and opts.Skip the initial offset.
+func (s *GroupsService) AllGroupUsers(ctx context.Context, groupID string, opts types.ListGroupUserOptions) iter.Seq2[types.GroupUser, error] {
+	if opts.Top <= 0 {
+		opts.Top = defaultPageSize
+	}
+	return offsetPager(ctx, opts.Top, opts.Skip, func(ctx context.Context, top, skip int) ([]types.GroupUser, error) {
+		opts := opts
+		opts.Top, opts.Skip = top, skip
+		return s.ListGroupUsers(ctx, groupID, opts)
+	})
+}
+
 // UpdateGroupUser updates a user's access in a workspace.
 func (s *GroupsService) UpdateGroupUser(ctx context.Context, groupID string, user types.GroupUser) error {
+	ctx = withOperation(ctx, "Groups.UpdateGroupUser")
 	u := fmt.Sprintf("%s/%s/users", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.putJSON(ctx, u, user)
 	if err != nil {
@@ -152,6 +212,7 @@ func (s *GroupsService) UpdateGroupUser(ctx context.Context, groupID string, use
 
 // AddDashboard creates a new empty dashboard in the specified workspace.
 func (s *GroupsService) AddDashboard(ctx context.Context, groupID string, req types.AddDashboardRequest) (*types.Dashboard, error) {
+	ctx = withOperation(ctx, "Groups.AddDashboard")
 	u := fmt.Sprintf("%s/%s/dashboards", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -164,6 +225,7 @@ func (s *GroupsService) AddDashboard(ctx context.Context, groupID string, req ty
 
 // DeleteDashboard deletes the specified dashboard from the specified workspace.
 func (s *GroupsService) DeleteDashboard(ctx context.Context, groupID, dashboardID string) error {
+	ctx = withOperation(ctx, "Groups.DeleteDashboard")
 	u := fmt.Sprintf("%s/%s/dashboards/%s", groupsBasePath, url.PathEscape(groupID), url.PathEscape(dashboardID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
@@ -176,6 +238,7 @@ func (s *GroupsService) DeleteDashboard(ctx context.Context, groupID, dashboardI
 
 // GetDashboard returns the specified dashboard from the specified workspace.
 func (s *GroupsService) GetDashboard(ctx context.Context, groupID, dashboardID string) (*types.Dashboard, error) {
+	ctx = withOperation(ctx, "Groups.GetDashboard")
 	u := fmt.Sprintf("%s/%s/dashboards/%s", groupsBasePath, url.PathEscape(groupID), url.PathEscape(dashboardID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -188,6 +251,7 @@ func (s *GroupsService) GetDashboard(ctx context.Context, groupID, dashboardID s
 
 // ListDashboards returns a list of dashboards from the specified workspace.
 func (s *GroupsService) ListDashboards(ctx context.Context, groupID string) ([]types.Dashboard, error) {
+	ctx = withOperation(ctx, "Groups.ListDashboards")
 	u := fmt.Sprintf("%s/%s/dashboards", groupsBasePath, url.PathEscape(groupID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -206,6 +270,7 @@ func (s *GroupsService) ListDashboards(ctx context.Context, groupID string) ([]t
 
 // CloneTile the specified tile from the specified workspace.
 func (s *GroupsService) CloneTile(ctx context.Context, groupID, dashboardID, tileID string, req types.CloneTileRequest) (*types.Tile, error) {
+	ctx = withOperation(ctx, "Groups.CloneTile")
 	u := fmt.Sprintf("%s/%s/dashboards/%s/tiles/%s/Clone", groupsBasePath, url.PathEscape(groupID), url.PathEscape(dashboardID), url.PathEscape(tileID))
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -218,6 +283,7 @@ func (s *GroupsService) CloneTile(ctx context.Context, groupID, dashboardID, til
 
 // GetTile returns the specified tile within the specified dashboard from the specified workspace.
 func (s *GroupsService) GetTile(ctx context.Context, groupID, dashboardID, tileID string) (*types.Tile, error) {
+	ctx = withOperation(ctx, "Groups.GetTile")
 	u := fmt.Sprintf("%s/%s/dashboards/%s/tiles/%s", groupsBasePath, url.PathEscape(groupID), url.PathEscape(dashboardID), url.PathEscape(tileID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -230,6 +296,7 @@ func (s *GroupsService) GetTile(ctx context.Context, groupID, dashboardID, tileI
 
 // ListTiles returns a list of tiles within the specified dashboard from the specified workspace.
 func (s *GroupsService) ListTiles(ctx context.Context, groupID, dashboardID string) ([]types.Tile, error) {
+	ctx = withOperation(ctx, "Groups.ListTiles")
 	u := fmt.Sprintf("%s/%s/dashboards/%s/tiles", groupsBasePath, url.PathEscape(groupID), url.PathEscape(dashboardID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -0,0 +1,355 @@
+// Command mockgen generates the mocks of the powerbimock package from the
+// service interfaces of the powerbi package.
+//
+// Usage:
+//
+//	mockgen -pkg dir -out file Interface=Mock...
+//
+// Each argument names an interface declared in the package in dir and the
+// mock type to generate for it. Every mock records its calls and forwards
+// them to a function field per method, named after the method with a Func
+// suffix.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath  = "github.com/stpabhi/powerbi-go"
+	mockPackage = "powerbimock"
+)
+
+func main() {
+	pkgDir := flag.String("pkg", ".", "directory of the powerbi package")
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("mockgen: ")
+
+	files, err := parseDir(*pkgDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g := &generator{imports: map[string]string{"powerbi": modulePath}}
+	for _, arg := range flag.Args() {
+		iface, mock, ok := strings.Cut(arg, "=")
+		if !ok {
+			log.Fatalf("invalid argument %q, want Interface=Mock", arg)
+		}
+		if err := g.generate(files, iface, mock); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	src, err := format.Source(g.file())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseDir parses the non-test Go files in dir.
+func parseDir(dir string) ([]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+type generator struct {
+	buf bytes.Buffer
+
+	// imports maps the package names used by the generated code to their
+	// import paths.
+	imports map[string]string
+}
+
+// file returns the generated source, including the package clause and
+// imports.
+func (g *generator) file() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by internal/mockgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", mockPackage)
+	names := make([]string, 0, len(g.imports))
+	for name := range g.imports {
+		names = append(names, name)
+	}
+	// Standard library imports come first, in a group of their own.
+	isStd := func(name string) bool {
+		first, _, _ := strings.Cut(g.imports[name], "/")
+		return !strings.Contains(first, ".")
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if isStd(a) != isStd(b) {
+			if isStd(a) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(g.imports[a], g.imports[b])
+	})
+	for i, name := range names {
+		if i > 0 && isStd(names[i-1]) && !isStd(name) {
+			b.WriteString("\n")
+		}
+		path := g.imports[name]
+		if filepath.Base(path) == name {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&b, "\t%s %q\n", name, path)
+		}
+	}
+	fmt.Fprintf(&b, ")\n")
+	b.Write(g.buf.Bytes())
+	return b.Bytes()
+}
+
+// generate writes the mock type mock for the interface named iface.
+func (g *generator) generate(files []*ast.File, iface, mock string) error {
+	spec, file := findInterface(files, iface)
+	if spec == nil {
+		return fmt.Errorf("interface %s not found", iface)
+	}
+	if spec.Methods == nil || len(spec.Methods.List) == 0 {
+		return fmt.Errorf("interface %s has no methods", iface)
+	}
+
+	type method struct {
+		name            string
+		params, results []param
+		variadic        bool
+	}
+	var methods []method
+	for _, field := range spec.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return fmt.Errorf("interface %s embeds %s, which is not supported", iface, g.expr(file, field.Type))
+		}
+		m := method{name: field.Names[0].Name}
+		m.params, m.variadic = g.params(file, ft.Params, "p")
+		m.results, _ = g.params(file, ft.Results, "")
+		methods = append(methods, m)
+	}
+	slices.SortFunc(methods, func(a, b method) int { return strings.Compare(a.name, b.name) })
+
+	w := &g.buf
+	fmt.Fprintf(w, "\nvar _ powerbi.%s = &%s{}\n\n", iface, mock)
+	fmt.Fprintf(w, "// %s is a mock implementation of powerbi.%s.\n", mock, iface)
+	fmt.Fprintf(w, "type %s struct {\n\trecorder\n\n", mock)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\t// %sFunc implements %s.\n", m.name, m.name)
+		fmt.Fprintf(w, "\t%sFunc func(%s) %s\n\n", m.name, signature(m.params, m.variadic, false), resultList(m.results))
+	}
+	fmt.Fprintf(w, "}\n")
+
+	for _, m := range methods {
+		var names []string
+		for _, p := range m.params {
+			names = append(names, p.name)
+		}
+		args := strings.Join(names, ", ")
+		if m.variadic {
+			args += "..."
+		}
+
+		fmt.Fprintf(w, "\n// %s records the call and calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", mock, m.name, signature(m.params, m.variadic, true), resultList(m.results))
+		fmt.Fprintf(w, "\tm.record(%q", m.name)
+		for _, name := range names {
+			fmt.Fprintf(w, ", %s", name)
+		}
+		fmt.Fprintf(w, ")\n")
+		fmt.Fprintf(w, "\tif m.%sFunc == nil {\n", m.name)
+		fmt.Fprintf(w, "\t\tpanic(%q)\n\t}\n", fmt.Sprintf("%s: %s.%s called but %sFunc is not set", mockPackage, mock, m.name, m.name))
+		if len(m.results) > 0 {
+			fmt.Fprintf(w, "\treturn ")
+		} else {
+			fmt.Fprintf(w, "\t")
+		}
+		fmt.Fprintf(w, "m.%sFunc(%s)\n}\n", m.name, args)
+	}
+	return nil
+}
+
+type param struct {
+	name string
+	typ  string
+}
+
+// params returns the parameters of fields with their types qualified for use
+// in the mock package. Unnamed parameters are named after prefix and their
+// position, or left unnamed if prefix is empty.
+func (g *generator) params(file *ast.File, fields *ast.FieldList, prefix string) (params []param, variadic bool) {
+	if fields == nil {
+		return nil, false
+	}
+	for _, field := range fields.List {
+		typ := field.Type
+		if e, ok := typ.(*ast.Ellipsis); ok {
+			variadic = true
+			typ = &ast.ArrayType{Elt: e.Elt}
+		}
+		t := g.expr(file, typ)
+		if len(field.Names) == 0 {
+			name := ""
+			if prefix != "" {
+				name = prefix + strconv.Itoa(len(params))
+			}
+			params = append(params, param{name: name, typ: t})
+			continue
+		}
+		for _, n := range field.Names {
+			params = append(params, param{name: n.Name, typ: t})
+		}
+	}
+	return params, variadic
+}
+
+// signature formats params as a parameter list, with or without names.
+func signature(params []param, variadic, named bool) string {
+	var parts []string
+	for i, p := range params {
+		t := p.typ
+		if variadic && i == len(params)-1 {
+			t = "..." + strings.TrimPrefix(t, "[]")
+		}
+		if named {
+			t = p.name + " " + t
+		}
+		parts = append(parts, t)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resultList formats results as a result list.
+func resultList(results []param) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return results[0].typ
+	}
+	var parts []string
+	for _, r := range results {
+		parts = append(parts, r.typ)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// expr formats the type expression e declared in file, qualifying the types
+// of the powerbi package and recording the imports it needs.
+func (g *generator) expr(file *ast.File, e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return "powerbi." + e.Name
+		}
+		return e.Name
+	case *ast.SelectorExpr:
+		pkg := e.X.(*ast.Ident).Name
+		g.imports[pkg] = importPath(file, pkg)
+		return pkg + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + g.expr(file, e.X)
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return "[" + g.expr(file, e.Len) + "]" + g.expr(file, e.Elt)
+		}
+		return "[]" + g.expr(file, e.Elt)
+	case *ast.MapType:
+		return "map[" + g.expr(file, e.Key) + "]" + g.expr(file, e.Value)
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.SEND:
+			return "chan<- " + g.expr(file, e.Value)
+		case ast.RECV:
+			return "<-chan " + g.expr(file, e.Value)
+		}
+		return "chan " + g.expr(file, e.Value)
+	case *ast.IndexExpr:
+		return g.expr(file, e.X) + "[" + g.expr(file, e.Index) + "]"
+	case *ast.IndexListExpr:
+		var args []string
+		for _, idx := range e.Indices {
+			args = append(args, g.expr(file, idx))
+		}
+		return g.expr(file, e.X) + "[" + strings.Join(args, ", ") + "]"
+	case *ast.FuncType:
+		params, variadic := g.params(file, e.Params, "")
+		results, _ := g.params(file, e.Results, "")
+		return strings.TrimSpace("func(" + signature(params, variadic, false) + ") " + resultList(results))
+	case *ast.InterfaceType:
+		if e.Methods == nil || len(e.Methods.List) == 0 {
+			return "any"
+		}
+	case *ast.BasicLit:
+		return e.Value
+	}
+	log.Fatalf("unsupported type expression %T", e)
+	return ""
+}
+
+// importPath returns the import path of the package imported as name by file.
+func importPath(file *ast.File, name string) string {
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil && spec.Name.Name == name || spec.Name == nil && filepath.Base(path) == name {
+			return path
+		}
+	}
+	log.Fatalf("no import for package %s", name)
+	return ""
+}
+
+// findInterface returns the interface type named name and the file declaring
+// it.
+func findInterface(files []*ast.File, name string) (*ast.InterfaceType, *ast.File) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if it, ok := ts.Type.(*ast.InterfaceType); ok && ts.Name.Name == name {
+					return it, f
+				}
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+package powerbi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody is the size of the largest body logged at debug level.
+const maxLoggedBody = 64 << 10
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged in clear.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// sensitiveFields are JSON fields whose values are redacted from logged
+// bodies: embed and access tokens, and data source credentials.
+var sensitiveFields = map[string]bool{
+	"token":         true,
+	"accesstoken":   true,
+	"access_token":  true,
+	"refresh_token": true,
+	"credentials":   true,
+	"password":      true,
+	"clientsecret":  true,
+	"client_secret": true,
+	"identityblob":  true,
+}
+
+// logRequests returns a Doer that logs each attempt of a request sent through
+// next. Bodies and headers are only logged at debug level, with secrets
+// redacted.
+func logRequests(logger *slog.Logger, next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		attrs := []slog.Attr{
+			slog.String("operation", Operation(ctx)),
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.RequestURI()),
+			slog.Int("attempt", Attempts(ctx)+1),
+		}
+		debug := logger.Enabled(ctx, slog.LevelDebug)
+		if debug {
+			attrs = append(attrs, slog.Any("request_headers", redactHeaders(req.Header)))
+			if body := requestBody(req); body != "" {
+				attrs = append(attrs, slog.String("request_body", body))
+			}
+		}
+
+		start := time.Now()
+		resp, err := next.Do(req)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+			logger.LogAttrs(ctx, slog.LevelError, "powerbi request failed", attrs...)
+			return resp, err
+		}
+
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if id := RequestID(resp.Header); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		if debug {
+			attrs = append(attrs, slog.Any("response_headers", redactHeaders(resp.Header)))
+			if body := responseBody(resp); body != "" {
+				attrs = append(attrs, slog.String("response_body", body))
+			}
+		}
+
+		level := slog.LevelInfo
+		if resp.StatusCode > 399 {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(ctx, level, "powerbi request", attrs...)
+		return resp, nil
+	})
+}
+
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{redacted}
+		}
+	}
+	return out
+}
+
+// requestBody returns a redacted copy of the body of req, leaving the body
+// itself untouched.
+func requestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	return redactBody(data)
+}
+
+// responseBody returns the redacted body of resp. It reads at most
+// maxLoggedBody+1 bytes, which it puts back in front of the rest of the body.
+func responseBody(resp *http.Response) string {
+	body := resp.Body
+	data, err := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), body), body}
+	if err != nil {
+		return ""
+	}
+	return redactBody(data)
+}
+
+// redactBody redacts the sensitive fields of a JSON body. Bodies larger than
+// maxLoggedBody are omitted, and other bodies that are not JSON are logged as is.
+func redactBody(data []byte) string {
+	if len(data) > maxLoggedBody {
+		return fmt.Sprintf("<%d+ bytes omitted>", maxLoggedBody)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(data)
+	}
+	return string(out)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if sensitiveFields[strings.ToLower(k)] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package powerbi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogRedactsSecrets(t *testing.T) {
+	c, mux := setup(t)
+	var logs bytes.Buffer
+	c.Logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	mux.HandleFunc("POST /v1.0/myorg/GenerateToken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token":"embed-secret","tokenId":"t1"}`))
+	})
+
+	_, resp, err := c.postJSON(context.Background(), "GenerateToken", map[string]any{
+		"datasets":   []map[string]string{{"id": "d1"}},
+		"identities": []map[string]string{{"username": "u", "password": "pw-secret"}},
+	}, "Authorization", "Bearer header-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "embed-secret") {
+		t.Errorf("response body = %q, want it intact after logging", body)
+	}
+
+	for _, secret := range []string{"embed-secret", "pw-secret", "header-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs.String())
+		}
+	}
+	if !strings.Contains(logs.String(), "tokenId") {
+		t.Errorf("logs miss the response body:\n%s", logs.String())
+	}
+}
+
+func TestLogLargeResponse(t *testing.T) {
+	c, mux := setup(t)
+	var logs bytes.Buffer
+	c.Logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	large := strings.Repeat("x", 3*maxLoggedBody)
+	mux.HandleFunc("GET /v1.0/myorg/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(large))
+	})
+
+	_, resp, err := c.doRequest(context.Background(), http.MethodGet, "large", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != large {
+		t.Errorf("read %d bytes of the response body, want %d", len(body), len(large))
+	}
+	if !strings.Contains(logs.String(), "bytes omitted") {
+		t.Errorf("logs do not omit the large body:\n%.200s", logs.String())
+	}
+}
@@ -0,0 +1,124 @@
+package powerbi
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client
+// implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doers.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer that sends the requests of every service method.
+// Middlewares see the request before any retry and the final response or
+// error, which is returned as is: error responses are only turned into
+// *types.ErrHTTP after the middleware chain. The name of the service method
+// is available from the request context through Operation.
+type Middleware func(next Doer) Doer
+
+// Use appends mw to the middleware chain of the client. The first middleware
+// is the outermost one. Retries, as configured by RetryPolicy, run inside the
+// chain, so each middleware is invoked once per service method call.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// doer returns the Doer that sends requests through the middleware chain.
+func (c *Client) doer() Doer {
+	var d Doer = DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if call := callFrom(req.Context()); call != nil {
+			call.attempts.Add(1)
+		}
+		hooks, _ := req.Context().Value(attemptHooksKey{}).([]AttemptHook)
+		if len(hooks) == 0 {
+			return c.HTTPClient.Do(req)
+		}
+		start := time.Now()
+		resp, err := c.HTTPClient.Do(req)
+		elapsed := time.Since(start)
+		for _, hook := range hooks {
+			hook(req, resp, err, elapsed)
+		}
+		return resp, err
+	})
+	if c.Logger != nil {
+		d = logRequests(c.Logger, d)
+	}
+	if c.RetryPolicy != nil {
+		d = c.RetryPolicy.wrap(d)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
+
+// AttemptHook observes a single HTTP attempt of a request: its response or
+// error and the time until the response headers were received.
+type AttemptHook func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+
+type attemptHooksKey struct{}
+
+// WithAttemptHook returns a copy of ctx in which hook is called after every
+// attempt of the requests made with it, including retries. Middlewares, which
+// see each service method call once, use it to observe the attempts made
+// inside them.
+func WithAttemptHook(ctx context.Context, hook AttemptHook) context.Context {
+	hooks, _ := ctx.Value(attemptHooksKey{}).([]AttemptHook)
+	return context.WithValue(ctx, attemptHooksKey{}, append(slices.Clip(hooks), hook))
+}
+
+type operationKey struct{}
+
+// withOperation records the name of the service method, such as
+// "Reports.CloneInGroup", issuing the requests made with ctx.
+func withOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// Operation returns the name of the service method, such as
+// "Reports.CloneInGroup", that issued the request made with ctx.
+func Operation(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+	return name
+}
+
+type callKey struct{}
+
+// call holds the state of a single service method request shared across the
+// middleware chain.
+type call struct {
+	attempts atomic.Int32
+}
+
+func withCall(ctx context.Context) (context.Context, *call) {
+	c := &call{}
+	return context.WithValue(ctx, callKey{}, c), c
+}
+
+func callFrom(ctx context.Context) *call {
+	c, _ := ctx.Value(callKey{}).(*call)
+	return c
+}
+
+// Attempts returns how many times the request made with ctx has been sent so
+// far, including retries. Middlewares can call it once the next Doer returns.
+func Attempts(ctx context.Context) int {
+	if c := callFrom(ctx); c != nil {
+		return int(c.attempts.Load())
+	}
+	return 0
+}
@@ -0,0 +1,152 @@
+package powerbi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// recordMiddleware returns a middleware appending "name>" to log before
+// calling the next Doer and "<name" after it returns.
+func recordMiddleware(name string, log *[]string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			*log = append(*log, name+">")
+			resp, err := next.Do(req)
+			*log = append(*log, "<"+name)
+			return resp, err
+		})
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	c, mux := setup(t)
+	var attempts atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `{"id":"g1"}`)
+	})
+
+	var log []string
+	c.Use(recordMiddleware("a", &log), recordMiddleware("b", &log))
+	c.Use(recordMiddleware("c", &log))
+	var gotAttempts int
+	c.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			gotAttempts = Attempts(req.Context())
+			return resp, err
+		})
+	})
+
+	if _, err := c.Groups.Get(context.Background(), "g1"); err != nil {
+		t.Fatal(err)
+	}
+	// The retry runs inside the chain, so each middleware runs once.
+	if want := []string{"a>", "b>", "c>", "<c", "<b", "<a"}; !slices.Equal(log, want) {
+		t.Errorf("middleware calls = %v, want %v", log, want)
+	}
+	if gotAttempts != 2 {
+		t.Errorf("Attempts() = %d, want 2", gotAttempts)
+	}
+}
+
+func TestOperationNames(t *testing.T) {
+	tests := []struct {
+		operation string
+		call      func(ctx context.Context, c *Client) error
+	}{
+		{"Groups.Get", func(ctx context.Context, c *Client) error {
+			_, err := c.Groups.Get(ctx, "g1")
+			return err
+		}},
+		{"Dashboards.List", func(ctx context.Context, c *Client) error {
+			_, err := c.Dashboards.List(ctx)
+			return err
+		}},
+		{"Reports.CloneInGroup", func(ctx context.Context, c *Client) error {
+			_, err := c.Reports.CloneInGroup(ctx, "g1", "r1", types.CloneReportRequest{Name: "Copy"})
+			return err
+		}},
+		{"PushDatasets.PostRows", func(ctx context.Context, c *Client) error {
+			return c.PushDatasets.PostRows(ctx, "d1", "Sales", types.PostRowsRequest{})
+		}},
+		{"Datasets.Dataset", func(ctx context.Context, c *Client) error {
+			_, err := c.Datasets.Dataset(ctx, "d1")
+			return err
+		}},
+		{"Datasets.Group.RefreshDataset", func(ctx context.Context, c *Client) error {
+			_, err := c.Datasets.Group().RefreshDataset(ctx, "g1", "d1", types.DatasetRefreshRequest{})
+			return err
+		}},
+		{"Admin.Groups.GetGroupAsAdmin", func(ctx context.Context, c *Client) error {
+			_, err := c.Admin.Groups().GetGroupAsAdmin(ctx, "g1", types.GroupOptions{})
+			return err
+		}},
+		{"Admin.Groups.AllGroupsAsAdmin", func(ctx context.Context, c *Client) error {
+			for _, err := range c.Admin.Groups().AllGroupsAsAdmin(ctx, types.GroupsOptions{}) {
+				return err
+			}
+			return nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			c, mux := setup(t)
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, `{}`)
+			})
+			var operations []string
+			c.Use(func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					operations = append(operations, Operation(req.Context()))
+					return next.Do(req)
+				})
+			})
+
+			if err := tt.call(context.Background(), c); err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{tt.operation}; !slices.Equal(operations, want) {
+				t.Errorf("operations = %v, want %v", operations, want)
+			}
+		})
+	}
+}
+
+func TestPollOperationName(t *testing.T) {
+	c, mux := setup(t)
+	var polls atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/groups/g1/datasets/d1/refreshes", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) == 1 {
+			io.WriteString(w, `{"value":[{"requestId":"r1","status":"Unknown"}]}`)
+			return
+		}
+		io.WriteString(w, `{"value":[{"requestId":"r1","status":"Completed"}]}`)
+	})
+	var operations []string
+	c.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			operations = append(operations, Operation(req.Context()))
+			return next.Do(req)
+		})
+	})
+
+	_, err := c.Datasets.Group().WaitForRefresh(context.Background(), "g1", "d1", "r1", WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(operations, ","); got != "Datasets.Group.WaitForRefresh,Datasets.Group.WaitForRefresh" {
+		t.Errorf("operations = %s, want two Datasets.Group.WaitForRefresh polls", got)
+	}
+}
@@ -0,0 +1,238 @@
+// Package otelpowerbi instruments the Power BI client with OpenTelemetry.
+//
+// Install the middleware on a client to get a span per service method call,
+// propagated to Power BI in the request headers, and request duration and
+// error metrics:
+//
+//	pbi.Use(otelpowerbi.Middleware())
+//
+// Clients without the middleware carry no instrumentation overhead.
+package otelpowerbi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/stpabhi/powerbi-go"
+)
+
+// ScopeName is the instrumentation scope name.
+const ScopeName = "github.com/stpabhi/powerbi-go/otelpowerbi"
+
+// Attribute keys specific to Power BI.
+const (
+	OperationKey   = attribute.Key("powerbi.operation")
+	WorkspaceIDKey = attribute.Key("powerbi.workspace.id")
+	DatasetIDKey   = attribute.Key("powerbi.dataset.id")
+	ReportIDKey    = attribute.Key("powerbi.report.id")
+	DashboardIDKey = attribute.Key("powerbi.dashboard.id")
+	RequestIDKey   = attribute.Key("powerbi.request.id")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. It defaults to the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider. It defaults to the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagator that injects the span context into the
+// request headers, such as traceparent. It defaults to the global one.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+// Middleware returns a powerbi.Middleware that records a client span per
+// service method call, named after the operation (such as
+// "Reports.CloneInGroup"), following the HTTP client semantic conventions,
+// and propagates its context in the request headers.
+// It also records the http.client.request.duration histogram for each HTTP
+// attempt, retries included, and the powerbi.client.request.errors counter for
+// each failed call.
+func Middleware(opts ...Option) powerbi.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of HTTP requests to the Power BI REST API, per attempt."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	errorCount, err := meter.Int64Counter("powerbi.client.request.errors",
+		metric.WithDescription("Number of failed Power BI REST API calls."),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next powerbi.Doer) powerbi.Doer {
+		return powerbi.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			op := powerbi.Operation(req.Context())
+			name := op
+			if name == "" {
+				name = req.Method
+			}
+
+			attrs := requestAttributes(req, op)
+			ctx, span := tracer.Start(req.Context(), name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			metricAttrs := []attribute.KeyValue{
+				OperationKey.String(op),
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.ServerAddress(req.URL.Hostname()),
+			}
+			if duration != nil {
+				ctx = powerbi.WithAttemptHook(ctx, func(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+					duration.Record(req.Context(), elapsed.Seconds(), metric.WithAttributes(attemptAttributes(metricAttrs, resp, err)...))
+				})
+			}
+
+			req = req.WithContext(ctx)
+			req.Header = req.Header.Clone()
+			cfg.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			resp, err := next.Do(req)
+
+			if attempts := powerbi.Attempts(ctx); attempts > 1 {
+				span.SetAttributes(semconv.HTTPRequestResendCount(attempts - 1))
+			}
+
+			var errType string
+			switch {
+			case err != nil:
+				errType = fmt.Sprintf("%T", err)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			default:
+				span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+				metricAttrs = append(metricAttrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+				if id := powerbi.RequestID(resp.Header); id != "" {
+					span.SetAttributes(RequestIDKey.String(id))
+				}
+				if resp.StatusCode > 399 {
+					errType = strconv.Itoa(resp.StatusCode)
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				}
+			}
+			if errType != "" {
+				span.SetAttributes(semconv.ErrorTypeKey.String(errType))
+				metricAttrs = append(metricAttrs, semconv.ErrorTypeKey.String(errType))
+			}
+
+			if errType != "" && errorCount != nil {
+				errorCount.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+			}
+			return resp, err
+		})
+	}
+}
+
+// attemptAttributes returns the metric attributes of an HTTP attempt, the
+// attributes of its call plus its status code or error type.
+func attemptAttributes(call []attribute.KeyValue, resp *http.Response, err error) []attribute.KeyValue {
+	attrs := slices.Clip(call)
+	switch {
+	case err != nil:
+		return append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	case resp.StatusCode > 399:
+		return append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode), semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+	}
+	return append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+}
+
+// requestAttributes returns the span attributes describing req.
+func requestAttributes(req *http.Request, op string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(op),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if port := req.URL.Port(); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			attrs = append(attrs, semconv.ServerPort(p))
+		}
+	} else if req.URL.Scheme == "https" {
+		attrs = append(attrs, semconv.ServerPort(443))
+	}
+	return append(attrs, pathAttributes(req.URL.Path)...)
+}
+
+// pathAttributes extracts the workspace, dataset, report and dashboard IDs
+// from a Power BI REST API path.
+func pathAttributes(path string) []attribute.KeyValue {
+	keys := map[string]attribute.Key{
+		"groups":     WorkspaceIDKey,
+		"datasets":   DatasetIDKey,
+		"reports":    ReportIDKey,
+		"dashboards": DashboardIDKey,
+	}
+
+	var attrs []attribute.KeyValue
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		key, ok := keys[segments[i]]
+		if !ok || isAction(segments[i+1]) {
+			continue
+		}
+		attrs = append(attrs, key.String(segments[i+1]))
+		i++
+	}
+	return attrs
+}
+
+// isAction reports whether a path segment following a collection name is an
+// action or sub-collection rather than an ID.
+func isAction(segment string) bool {
+	switch segment {
+	case "GenerateToken", "upstreamDataflows":
+		return true
+	}
+	return false
+}
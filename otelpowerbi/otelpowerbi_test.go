@@ -0,0 +1,221 @@
+package otelpowerbi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/otelpowerbi"
+)
+
+// setup returns a client instrumented with in-memory exporters, sending its
+// requests to handler.
+func setup(t *testing.T, handler http.HandlerFunc) (*powerbi.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	c := powerbi.NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/v1.0/myorg/")
+	c.RetryPolicy = &powerbi.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	c.Use(otelpowerbi.Middleware(otelpowerbi.WithTracerProvider(tp), otelpowerbi.WithMeterProvider(mp)))
+	return c, spans, reader
+}
+
+func attributes(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestSpan(t *testing.T) {
+	var attempts atomic.Int32
+	c, spans, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RequestId", "req-1")
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"d1"}`))
+	})
+
+	if _, err := c.Datasets.Group().Dataset(context.Background(), "g1", "d1"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("got %d spans, want 1 per call", len(got))
+	}
+	span := got[0]
+	if span.Name != "Datasets.Group.Dataset" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span %q of kind %v, want client span Datasets.Group.Dataset", span.Name, span.SpanKind)
+	}
+	if span.Status.Code == codes.Error {
+		t.Errorf("span status = %v, want unset", span.Status)
+	}
+	attrs := attributes(span.Attributes)
+	want := map[attribute.Key]attribute.Value{
+		otelpowerbi.OperationKey:    attribute.StringValue("Datasets.Group.Dataset"),
+		otelpowerbi.WorkspaceIDKey:  attribute.StringValue("g1"),
+		otelpowerbi.DatasetIDKey:    attribute.StringValue("d1"),
+		otelpowerbi.RequestIDKey:    attribute.StringValue("req-1"),
+		"http.request.method":       attribute.StringValue("GET"),
+		"http.response.status_code": attribute.IntValue(200),
+		"http.request.resend_count": attribute.IntValue(1),
+	}
+	for k, v := range want {
+		if attrs[k] != v {
+			t.Errorf("attribute %s = %v, want %v", k, attrs[k].Emit(), v.Emit())
+		}
+	}
+}
+
+func TestSpanError(t *testing.T) {
+	c, spans, reader := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if err := c.Reports.DeleteInGroup(context.Background(), "g1", "r1"); err == nil {
+		t.Fatal("want an error")
+	}
+
+	span := spans.GetSpans()[0]
+	if span.Status.Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status)
+	}
+	attrs := attributes(span.Attributes)
+	if attrs["error.type"].AsString() != "404" || attrs[otelpowerbi.ReportIDKey].AsString() != "r1" {
+		t.Errorf("error.type = %q, report ID = %q", attrs["error.type"].Emit(), attrs[otelpowerbi.ReportIDKey].Emit())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	errors, ok := findMetric(rm, "powerbi.client.request.errors").(metricdata.Sum[int64])
+	if !ok || len(errors.DataPoints) != 1 || errors.DataPoints[0].Value != 1 {
+		t.Errorf("powerbi.client.request.errors = %+v, want 1", errors)
+	}
+}
+
+func TestDurationPerAttempt(t *testing.T) {
+	var attempts atomic.Int32
+	c, _, reader := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"d1"}`))
+	})
+
+	if _, err := c.Datasets.Group().Dataset(context.Background(), "g1", "d1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	hist, ok := findMetric(rm, "http.client.request.duration").(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatal("http.client.request.duration not recorded")
+	}
+	counts := map[int64]uint64{}
+	for _, dp := range hist.DataPoints {
+		status, _ := dp.Attributes.Value("http.response.status_code")
+		counts[status.AsInt64()] += dp.Count
+	}
+	if counts[429] != 2 || counts[200] != 1 {
+		t.Errorf("duration counts by status = %v, want 2 for 429 and 1 for 200", counts)
+	}
+	if findMetric(rm, "powerbi.client.request.errors") != nil {
+		t.Error("powerbi.client.request.errors recorded for a call that succeeded")
+	}
+}
+
+func findMetric(rm metricdata.ResourceMetrics, name string) metricdata.Aggregation {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+	return nil
+}
+
+func TestPropagation(t *testing.T) {
+	var traceparents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		if len(traceparents) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+
+	c := powerbi.NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/v1.0/myorg/")
+	c.RetryPolicy = &powerbi.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	c.Use(otelpowerbi.Middleware(otelpowerbi.WithTracerProvider(tp), otelpowerbi.WithPropagators(propagation.TraceContext{})))
+
+	if _, err := c.Groups.Get(context.Background(), "g1"); err != nil {
+		t.Fatal(err)
+	}
+	span := spans.GetSpans()[0].SpanContext
+	want := "00-" + span.TraceID().String() + "-" + span.SpanID().String() + "-01"
+	if len(traceparents) != 2 {
+		t.Fatalf("%d requests, want 2", len(traceparents))
+	}
+	for i, got := range traceparents {
+		if got != want {
+			t.Errorf("attempt %d traceparent = %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestPropagationGlobal(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+
+	var traceparent string
+	c, spans, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{}`))
+	})
+	if _, err := c.Groups.Get(context.Background(), "g1"); err != nil {
+		t.Fatal(err)
+	}
+	span := spans.GetSpans()[0].SpanContext
+	if want := "00-" + span.TraceID().String() + "-" + span.SpanID().String() + "-01"; traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+}
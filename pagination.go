@@ -0,0 +1,65 @@
+package powerbi
+
+import (
+	"context"
+	"iter"
+)
+
+const (
+	// defaultPageSize is the $top used by iterators when the caller sets none.
+	defaultPageSize = 1000
+
+	// maxAdminPageSize is the largest $top accepted by the admin APIs.
+	maxAdminPageSize = 5000
+)
+
+// offsetPager returns an iterator over the items of an endpoint paged with
+// $top and $skip. list is called with successive skip values until it returns
+// a page shorter than top. The iterator can be ranged over more than once.
+func offsetPager[T any](ctx context.Context, top, first int, list func(ctx context.Context, top, skip int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for skip := first; ; {
+			page, err := list(ctx, top, skip)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, v := range page {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if len(page) < top {
+				return
+			}
+			skip += len(page)
+		}
+	}
+}
+
+// continuationPager returns an iterator over the items of an endpoint paged
+// with continuation links. fetch is called with u and then with each
+// continuation link it returns, until it returns an empty link. The iterator
+// can be ranged over more than once.
+func continuationPager[T any](ctx context.Context, first string, fetch func(ctx context.Context, u string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for u := first; u != ""; {
+			page, next, err := fetch(ctx, u)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, v := range page {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if next == u {
+				return
+			}
+			u = next
+		}
+	}
+}
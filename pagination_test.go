@@ -0,0 +1,67 @@
+package powerbi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestGroupsAllRangedTwice(t *testing.T) {
+	c, mux := setup(t)
+	var groups []types.Group
+	for i := range 5 {
+		groups = append(groups, types.Group{ID: fmt.Sprintf("g%d", i)})
+	}
+	mux.HandleFunc("GET /v1.0/myorg/groups", func(w http.ResponseWriter, r *http.Request) {
+		top, _ := strconv.Atoi(r.URL.Query().Get("$top"))
+		skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+		page := groups[min(skip, len(groups)):min(skip+top, len(groups))]
+		json.NewEncoder(w).Encode(types.GroupList{Value: page})
+	})
+
+	seq := c.Groups.All(context.Background(), types.ListGroupsOptions{Top: 2})
+	for pass := 1; pass <= 2; pass++ {
+		var ids []string
+		for g, err := range seq {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, g.ID)
+		}
+		if got, want := fmt.Sprint(ids), "[g0 g1 g2 g3 g4]"; got != want {
+			t.Errorf("pass %d: groups = %s, want %s", pass, got, want)
+		}
+	}
+}
+
+func TestAllUnusedArtifactsRangedTwice(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/admin/groups/g1/unused", func(w http.ResponseWriter, r *http.Request) {
+		resp := types.UnusedArtifactsResponse{ContinuationToken: "page2"}
+		id := "a1"
+		if r.URL.Query().Get("continuationToken") == "page2" {
+			resp.ContinuationToken, id = "", "a2"
+		}
+		resp.UnusedArtifactEntities = []types.UnusedArtifactEntity{{ArtifactID: id}}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	seq := c.Admin.Groups().AllUnusedArtifactsAsAdmin(context.Background(), "g1", types.UnusedArtifactsOptions{})
+	for pass := 1; pass <= 2; pass++ {
+		var ids []string
+		for a, err := range seq {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, a.ArtifactID)
+		}
+		if got, want := fmt.Sprint(ids), "[a1 a2]"; got != want {
+			t.Errorf("pass %d: artifacts = %s, want %s", pass, got, want)
+		}
+	}
+}
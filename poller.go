@@ -0,0 +1,239 @@
+package powerbi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// Default polling intervals of a Poller.
+const (
+	defaultPollInterval    = 5 * time.Second
+	defaultMaxPollInterval = time.Minute
+)
+
+// ErrPollerDone is returned by Poller.Poll when the operation already reached
+// a terminal state.
+var ErrPollerDone = errors.New("powerbi: poller is done")
+
+// PollerOptions configures a Poller.
+type PollerOptions[T any] struct {
+	// Done reports whether the operation described by a status is in a
+	// terminal state, and the error to return if it failed. A nil Done
+	// considers the operation done as soon as the status URL answers with
+	// a status other than 202 Accepted.
+	Done func(status *T) (bool, error)
+
+	// Interval is the delay before the second poll. Later delays double up
+	// to MaxInterval. A Retry-After header takes precedence. It defaults to
+	// 5 seconds.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between two polls. It defaults to a minute.
+	MaxInterval time.Duration
+
+	// Timeout bounds the time PollUntilDone waits for the operation. A zero
+	// Timeout waits until the context is done.
+	Timeout time.Duration
+
+	// Operation names the poll requests for middleware, such as
+	// "Datasets.Group.GetRefreshExecutionDetails". It defaults to "Poller.Poll".
+	Operation string
+}
+
+// WaitOptions configures how the Wait methods of the services, such as
+// WaitForRefresh, poll long-running operations.
+type WaitOptions struct {
+	// Interval is the delay before the second poll. Later delays double up
+	// to MaxInterval. It defaults to 5 seconds.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between two polls. It defaults to a minute.
+	MaxInterval time.Duration
+
+	// Timeout bounds the wait. A zero Timeout waits until the context is
+	// done.
+	Timeout time.Duration
+}
+
+// Poller polls the status URL of a long-running operation, such as an
+// enhanced dataset refresh or an export to file, until it reaches a terminal
+// state. T is the type the status URL answers with.
+//
+// A Poller is not safe for concurrent use. It can be serialized with
+// ResumeToken and recreated with ResumePoller, for instance after a process
+// restart.
+type Poller[T any] struct {
+	client *Client
+	opts   PollerOptions[T]
+
+	url        string
+	status     *T
+	done       bool
+	err        error
+	retryAfter time.Duration
+}
+
+// NewPoller returns a Poller for the status URL of a long-running operation,
+// usually the Location header of the 202 Accepted response that started it.
+// The URL may be absolute or relative to the client's BaseURL.
+func NewPoller[T any](c *Client, statusURL string, opts PollerOptions[T]) (*Poller[T], error) {
+	if statusURL == "" {
+		return nil, errors.New("powerbi: poller status URL is empty")
+	}
+	if _, err := c.BaseURL.Parse(statusURL); err != nil {
+		return nil, fmt.Errorf("powerbi: invalid poller status URL: %w", err)
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultPollInterval
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = defaultMaxPollInterval
+	}
+	opts.MaxInterval = max(opts.MaxInterval, opts.Interval)
+	if opts.Operation == "" {
+		opts.Operation = "Poller.Poll"
+	}
+	return &Poller[T]{client: c, opts: opts, url: statusURL}, nil
+}
+
+// resumeToken is the serialized form of a Poller.
+type resumeToken struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// ResumePoller returns a Poller for the operation serialized in token by
+// ResumeToken. opts configures the new Poller like in NewPoller.
+func ResumePoller[T any](c *Client, token string, opts PollerOptions[T]) (*Poller[T], error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("powerbi: invalid resume token: %w", err)
+	}
+	var rt resumeToken
+	if err := json.Unmarshal(data, &rt); err != nil {
+		return nil, fmt.Errorf("powerbi: invalid resume token: %w", err)
+	}
+	if want := statusType[T](); rt.Type != want {
+		return nil, fmt.Errorf("powerbi: resume token is for a poller of %s, not %s", rt.Type, want)
+	}
+	return NewPoller(c, rt.URL, opts)
+}
+
+// ResumeToken returns a token from which ResumePoller recreates p. It fails
+// once the operation is done.
+func (p *Poller[T]) ResumeToken() (string, error) {
+	if p.done {
+		return "", ErrPollerDone
+	}
+	data, err := json.Marshal(resumeToken{Type: statusType[T](), URL: p.url})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// statusType names T in resume tokens.
+func statusType[T any]() string {
+	t := reflect.TypeFor[T]()
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// Done reports whether the operation reached a terminal state.
+func (p *Poller[T]) Done() bool {
+	return p.done
+}
+
+// Result returns the last status polled and, once the operation is done, the
+// error it failed with, if any.
+func (p *Poller[T]) Result() (*T, error) {
+	return p.status, p.err
+}
+
+// Poll fetches the status of the operation once. It returns the status and,
+// if the operation reached a terminal state in failure, its error. Errors
+// fetching the status are returned without changing the state of the Poller.
+func (p *Poller[T]) Poll(ctx context.Context) (*T, error) {
+	if p.done {
+		return p.status, ErrPollerDone
+	}
+	ctx = withOperation(ctx, p.opts.Operation)
+	_, resp, err := p.client.doRequest(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	p.retryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	accepted := resp.StatusCode == http.StatusAccepted
+	if location := resp.Header.Get("Location"); accepted && location != "" {
+		p.url = location
+	}
+
+	status := new(T)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, status); err != nil {
+			return nil, err
+		}
+	}
+	p.status = status
+
+	if p.opts.Done != nil {
+		p.done, p.err = p.opts.Done(status)
+	} else {
+		p.done = !accepted
+	}
+	if !p.done {
+		p.err = nil
+	}
+	return status, p.err
+}
+
+// PollUntilDone polls the operation until it reaches a terminal state, the
+// context is done or the Timeout of the options elapses. Polls are spaced by
+// the Retry-After header of the status URL or, without one, by an exponential
+// backoff between Interval and MaxInterval. It returns the final status and,
+// if the operation failed, its error.
+func (p *Poller[T]) PollUntilDone(ctx context.Context) (*T, error) {
+	if p.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.opts.Timeout)
+		defer cancel()
+	}
+
+	interval := p.opts.Interval
+	for !p.done {
+		status, err := p.Poll(ctx)
+		if err != nil || p.done {
+			return status, err
+		}
+
+		delay := interval
+		if p.retryAfter > 0 {
+			delay = p.retryAfter
+		}
+		interval = min(2*interval, p.opts.MaxInterval)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return p.status, p.err
+}
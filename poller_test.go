@@ -0,0 +1,184 @@
+package powerbi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testOperation struct {
+	Status string `json:"status"`
+}
+
+func testOperationDone(op *testOperation) (bool, error) {
+	switch op.Status {
+	case "Succeeded":
+		return true, nil
+	case "Failed":
+		return true, errors.New("operation failed")
+	}
+	return false, nil
+}
+
+func TestPollerFollowsLocation(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/ops/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/v1.0/myorg/ops/2")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	var polls atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/ops/2", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < 2 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		io.WriteString(w, `{"status":"Succeeded"}`)
+	})
+
+	p, err := NewPoller[testOperation](c, "ops/1", PollerOptions[testOperation]{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, err := p.PollUntilDone(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Status != "Succeeded" || !p.Done() {
+		t.Errorf("PollUntilDone() = %+v, done %v, want Succeeded and done", op, p.Done())
+	}
+	if got := polls.Load(); got != 2 {
+		t.Errorf("%d polls of the Location, want 2", got)
+	}
+	if _, err := p.Poll(context.Background()); !errors.Is(err, ErrPollerDone) {
+		t.Errorf("Poll() after done: error = %v, want ErrPollerDone", err)
+	}
+}
+
+func TestPollerDone(t *testing.T) {
+	c, mux := setup(t)
+	var polls atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/ops/1", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < 2 {
+			io.WriteString(w, `{"status":"Running"}`)
+			return
+		}
+		io.WriteString(w, `{"status":"Failed"}`)
+	})
+
+	p, err := NewPoller(c, "ops/1", PollerOptions[testOperation]{Done: testOperationDone, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, err := p.PollUntilDone(context.Background())
+	if err == nil || err.Error() != "operation failed" {
+		t.Errorf("PollUntilDone() error = %v, want operation failed", err)
+	}
+	if status, resultErr := p.Result(); op.Status != "Failed" || status != op || resultErr != err {
+		t.Errorf("Result() = %+v, %v, want the final status and error", status, resultErr)
+	}
+}
+
+func TestPollerRetryAfter(t *testing.T) {
+	c, mux := setup(t)
+	var polls atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/ops/1", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Without the Retry-After header, the poller would wait an hour.
+	p, err := NewPoller(c, "ops/1", PollerOptions[testOperation]{Interval: time.Hour, Timeout: 10 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := p.PollUntilDone(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("PollUntilDone() took %v, want at least the Retry-After second", elapsed)
+	}
+	if got := polls.Load(); got != 2 {
+		t.Errorf("%d polls, want 2", got)
+	}
+}
+
+func TestPollerTimeout(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/ops/1", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"Running"}`)
+	})
+
+	p, err := NewPoller(c, "ops/1", PollerOptions[testOperation]{
+		Done:     testOperationDone,
+		Interval: time.Millisecond,
+		Timeout:  20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, err := p.PollUntilDone(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PollUntilDone() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if op == nil || op.Status != "Running" || p.Done() {
+		t.Errorf("PollUntilDone() = %+v, done %v, want the last status and not done", op, p.Done())
+	}
+}
+
+func TestPollerResumeToken(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/ops/2", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"Succeeded"}`)
+	})
+	mux.HandleFunc("GET /v1.0/myorg/ops/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/v1.0/myorg/ops/2")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	ctx := context.Background()
+	p, err := NewPoller(c, "ops/1", PollerOptions[testOperation]{Done: testOperationDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	token, err := p.ResumeToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The resumed poller polls the Location the first one moved to.
+	resumed, err := ResumePoller(c, token, PollerOptions[testOperation]{Done: testOperationDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, err := resumed.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Status != "Succeeded" || !resumed.Done() {
+		t.Errorf("Poll() of the resumed poller = %+v, done %v, want Succeeded and done", op, resumed.Done())
+	}
+	if _, err := resumed.ResumeToken(); !errors.Is(err, ErrPollerDone) {
+		t.Errorf("ResumeToken() after done: error = %v, want ErrPollerDone", err)
+	}
+
+	if _, err := ResumePoller[map[string]any](c, token, PollerOptions[map[string]any]{}); err == nil {
+		t.Error("ResumePoller() of another status type succeeded")
+	}
+	for _, token := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := ResumePoller(c, token, PollerOptions[testOperation]{}); err == nil {
+			t.Errorf("ResumePoller(%q) succeeded", token)
+		}
+	}
+}
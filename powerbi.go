@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 
@@ -18,7 +20,6 @@ import (
 
 const (
 	version   = "1.0.0"
-	baseURL   = "https://api.powerbi.com/v1.0/myorg/"
 	userAgent = "powerbi-go/" + version
 	mediaType = "application/json"
 )
@@ -31,18 +32,38 @@ type Client struct {
 	// Base URL for API requests. BaseURL should always be specified with a trailing slash.
 	BaseURL *url.URL
 
+	// Cloud is the Power BI cloud environment the client talks to. Use SetCloud
+	// to change it together with BaseURL.
+	Cloud Cloud
+
 	// User agent for client.
 	UserAgent string
 
+	// RetryPolicy controls how throttled and transient failures are retried.
+	// A nil RetryPolicy disables retries.
+	RetryPolicy *RetryPolicy
+
+	// Logger logs every request attempt with its method, path, duration,
+	// status and Power BI request ID. At debug level, headers and bodies are
+	// logged too, with credentials and tokens redacted. A nil Logger disables
+	// logging.
+	Logger *slog.Logger
+
 	// Reuse a single struct instead of allocating one for each service on the heap.
 	common service
 
+	middleware []Middleware
+
+	// profileID is the service principal profile set by WithProfile.
+	profileID string
+
 	// Add services here
 	Admin        *AdminService
 	Dashboards   *DashboardsService
 	Datasets     *DatasetsService
 	EmbedToken   *EmbedTokenService
 	Groups       *GroupsService
+	Profiles     *ProfilesService
 	PushDatasets *PushDatasetsService
 	Reports      *ReportsService
 }
@@ -91,9 +112,15 @@ func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
-	baseURL, _ := url.Parse(baseURL)
+	baseURL, _ := url.Parse(CloudPublic.APIBaseURL)
 
-	c := &Client{HTTPClient: httpClient, BaseURL: baseURL, UserAgent: userAgent}
+	c := &Client{HTTPClient: httpClient, BaseURL: baseURL, Cloud: CloudPublic, UserAgent: userAgent, RetryPolicy: DefaultRetryPolicy()}
+	c.initServices()
+
+	return c
+}
+
+func (c *Client) initServices() {
 	c.common.client = c
 
 	// Add services here
@@ -102,18 +129,57 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Datasets = (*DatasetsService)(&c.common)
 	c.EmbedToken = (*EmbedTokenService)(&c.common)
 	c.Groups = (*GroupsService)(&c.common)
+	c.Profiles = (*ProfilesService)(&c.common)
 	c.PushDatasets = (*PushDatasetsService)(&c.common)
 	c.Reports = (*ReportsService)(&c.common)
+}
 
-	return c
+var _ ClientInterface = &Client{}
+
+// ClientInterface is the interface implemented by Client. Code that only
+// needs the services can accept a ClientInterface, or the narrower service
+// interfaces, and be tested with the mocks of the powerbimock package.
+type ClientInterface interface {
+	AdminAPI() Admin
+	DashboardsAPI() DashboardsInterface
+	DatasetsAPI() DatasetsInterface
+	EmbedTokenAPI() EmbedTokenInterface
+	GroupsAPI() GroupsInterface
+	ProfilesAPI() ProfilesInterface
+	PushDatasetsAPI() PushDatasetsInterface
+	ReportsAPI() ReportsInterface
 }
 
+// AdminAPI returns c.Admin.
+func (c *Client) AdminAPI() Admin { return c.Admin }
+
+// DashboardsAPI returns c.Dashboards.
+func (c *Client) DashboardsAPI() DashboardsInterface { return c.Dashboards }
+
+// DatasetsAPI returns c.Datasets.
+func (c *Client) DatasetsAPI() DatasetsInterface { return c.Datasets }
+
+// EmbedTokenAPI returns c.EmbedToken.
+func (c *Client) EmbedTokenAPI() EmbedTokenInterface { return c.EmbedToken }
+
+// GroupsAPI returns c.Groups.
+func (c *Client) GroupsAPI() GroupsInterface { return c.Groups }
+
+// ProfilesAPI returns c.Profiles.
+func (c *Client) ProfilesAPI() ProfilesInterface { return c.Profiles }
+
+// PushDatasetsAPI returns c.PushDatasets.
+func (c *Client) PushDatasetsAPI() PushDatasetsInterface { return c.PushDatasets }
+
+// ReportsAPI returns c.Reports.
+func (c *Client) ReportsAPI() ReportsInterface { return c.Reports }
+
 func (c *Client) patchJSON(ctx context.Context, path string, obj any, headerKV ...string) (*http.Request, *http.Response, error) {
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.doRequest(ctx, http.MethodPatch, path, bytes.NewBuffer(data), append(headerKV, "Content-Type", mediaType)...)
+	return c.doRequest(ctx, http.MethodPatch, path, bytes.NewReader(data), append(headerKV, "Content-Type", mediaType)...)
 }
 
 func (c *Client) postJSON(ctx context.Context, path string, obj any, headerKV ...string) (*http.Request, *http.Response, error) {
@@ -129,7 +195,7 @@ func (c *Client) postJSON(ctx context.Context, path string, obj any, headerKV ..
 		if err != nil {
 			return nil, nil, err
 		}
-		body = bytes.NewBuffer(data)
+		body = bytes.NewReader(data)
 		headerKV = append(headerKV, "Content-Type", mediaType)
 	}
 	return c.doRequest(ctx, http.MethodPost, path, body, headerKV...)
@@ -140,7 +206,7 @@ func (c *Client) putJSON(ctx context.Context, path string, obj any, headerKV ...
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.doRequest(ctx, http.MethodPut, path, bytes.NewBuffer(data), append(headerKV, "Content-Type", mediaType)...)
+	return c.doRequest(ctx, http.MethodPut, path, bytes.NewReader(data), append(headerKV, "Content-Type", mediaType)...)
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader, headerKV ...string) (*http.Request, *http.Response, error) {
@@ -148,6 +214,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	if err != nil {
 		return nil, nil, err
 	}
+	ctx, _ = withCall(ctx)
 
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
@@ -160,24 +227,34 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	for i := 0; i < len(headerKV); i += 2 {
 		req.Header.Add(headerKV[i], headerKV[i+1])
 	}
-	resp, err := c.HTTPClient.Do(req)
+	if profileID := c.profileFor(ctx); profileID != "" {
+		req.Header.Set(profileHeader, profileID)
+	}
+	resp, err := c.doer().Do(req)
 	if err != nil {
 		return nil, nil, err
 	}
+	captureResponse(ctx, resp)
 	if resp.StatusCode > 399 {
+		defer resp.Body.Close()
 		data, _ := io.ReadAll(resp.Body)
-		msg := string(data)
-		if len(msg) == 0 {
-			msg = resp.Status
-		}
-		return nil, nil, &types.ErrHTTP{
-			Code:    resp.StatusCode,
-			Message: msg,
-		}
+		errHTTP := types.ParseErrHTTP(resp.StatusCode, data)
+		errHTTP.RequestID = RequestID(resp.Header)
+		errHTTP.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, nil, errHTTP
 	}
 	return req, resp, err
 }
 
+// RequestID returns the Power BI request ID of a response from its headers,
+// for instance to quote to Microsoft support or to log from a Middleware.
+func RequestID(h http.Header) string {
+	if id := h.Get("RequestId"); id != "" {
+		return id
+	}
+	return h.Get("x-ms-request-id")
+}
+
 func toObject[T any](resp *http.Response, obj T) (def T, _ error) {
 	defer resp.Body.Close()
 	if err := json.NewDecoder(resp.Body).Decode(&obj); err != nil {
@@ -209,15 +286,56 @@ func setCredentialsAsHeaders(req *http.Request, token string) *http.Request {
 type TokenTransport struct {
 	AccessToken string
 
+	// Provider supplies the token for each request and takes precedence over
+	// AccessToken. If Provider also has an Invalidate(token string) method,
+	// such as CachedTokenProvider, a request rejected with 401 Unauthorized is
+	// retried once with a freshly fetched token.
+	Provider TokenProvider
+
 	// Transport is the underlying HTTP transport to use when making requests.
 	// It will default to http.DefaultTransport if nil.
 	Transport http.RoundTripper
 }
 
+// tokenInvalidator is implemented by token providers that can discard a
+// cached token that Power BI rejected.
+type tokenInvalidator interface {
+	Invalidate(token string)
+}
+
 // RoundTrip implements the RoundTripper interface.
 func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req2 := setCredentialsAsHeaders(req, t.AccessToken)
-	return t.transport().RoundTrip(req2)
+	if t.Provider == nil {
+		req2 := setCredentialsAsHeaders(req, t.AccessToken)
+		return t.transport().RoundTrip(req2)
+	}
+
+	token, err := t.Provider.GetToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.transport().RoundTrip(setCredentialsAsHeaders(req, token.AccessToken))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	inv, ok := t.Provider.(tokenInvalidator)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	inv.Invalidate(token.AccessToken)
+	token, err = t.Provider.GetToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req2, err := rewindRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.transport().RoundTrip(setCredentialsAsHeaders(req2, token.AccessToken))
 }
 
 // Client returns an *http.Client that makes requests that are authenticated
@@ -0,0 +1,79 @@
+package powerbi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// setup returns a client sending its requests to a test server that serves
+// mux. Retries back off for at most a millisecond.
+func setup(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/v1.0/myorg/")
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 4, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	return c, mux
+}
+
+// routeTest is a client call expected to send a request matching pattern,
+// such as "GET /v1.0/myorg/datasets/d1", with the query string query and the
+// JSON body body if set. The server answers it with the JSON response, or with
+// {} if it is empty.
+type routeTest struct {
+	pattern  string
+	query    string
+	body     string
+	response string
+	call     func(ctx context.Context, c *Client) error
+}
+
+// testRoutes runs each call against a server that only serves its pattern.
+func testRoutes(t *testing.T, tests []routeTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			c, mux := setup(t)
+			called := false
+			mux.HandleFunc(tt.pattern, func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if tt.query != "" && r.URL.RawQuery != tt.query {
+					t.Errorf("query = %s, want %s", r.URL.RawQuery, tt.query)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if tt.body != "" && string(body) != tt.body {
+					t.Errorf("body = %s, want %s", body, tt.body)
+				}
+				response := tt.response
+				if response == "" {
+					response = "{}"
+				}
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, response)
+			})
+			if err := tt.call(context.Background(), c); err != nil {
+				t.Fatal(err)
+			}
+			if !called {
+				t.Errorf("no request matched %s", tt.pattern)
+			}
+		})
+	}
+}
+
+// checkLen returns err, or an error if list does not have n elements.
+func checkLen[T any](list []T, err error, n int) error {
+	if err == nil && len(list) != n {
+		err = fmt.Errorf("got %d elements, want %d", len(list), n)
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+// Package powerbimock provides mock implementations of the service interfaces
+// of the powerbi package.
+//
+// Every mock has a function field per method, named after the method with a
+// Func suffix, which the method calls with its arguments. Calling a method
+// whose function is not set panics. Every call is recorded and can be
+// inspected with Calls:
+//
+//	reports := &powerbimock.ReportsMock{
+//		GetInGroupFunc: func(ctx context.Context, groupID, reportID string) (*types.Report, error) {
+//			return &types.Report{ID: reportID, Name: "Sales"}, nil
+//		},
+//	}
+//	err := publish(ctx, reports)
+//	fmt.Println(reports.Calls())
+//
+// The mocks are generated by internal/mockgen; run go generate ./... after
+// changing a service interface.
+package powerbimock
+
+//go:generate go run ../internal/mockgen -pkg .. -out mocks.go ClientInterface=ClientMock Admin=AdminMock Groups=AdminGroupsMock DashboardsInterface=DashboardsMock DatasetsInterface=DatasetsMock DatasetGroup=DatasetGroupMock EmbedTokenInterface=EmbedTokenMock GroupsInterface=GroupsMock ProfilesInterface=ProfilesMock PushDatasetsInterface=PushDatasetsMock ReportsInterface=ReportsMock
+
+import "sync"
+
+// Call is a recorded call of a mock method.
+type Call struct {
+	// Method is the name of the called method.
+	Method string
+
+	// Args are the arguments of the call, in order. The arguments of a
+	// variadic parameter are recorded as a slice.
+	Args []any
+}
+
+// recorder records the calls of a mock. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the recorded calls, in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls of method, in order.
+func (r *recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
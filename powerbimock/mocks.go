@@ -0,0 +1,1648 @@
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package powerbimock
+
+import (
+	"context"
+	"iter"
+
+	powerbi "github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+var _ powerbi.ClientInterface = &ClientMock{}
+
+// ClientMock is a mock implementation of powerbi.ClientInterface.
+type ClientMock struct {
+	recorder
+
+	// AdminAPIFunc implements AdminAPI.
+	AdminAPIFunc func() powerbi.Admin
+
+	// DashboardsAPIFunc implements DashboardsAPI.
+	DashboardsAPIFunc func() powerbi.DashboardsInterface
+
+	// DatasetsAPIFunc implements DatasetsAPI.
+	DatasetsAPIFunc func() powerbi.DatasetsInterface
+
+	// EmbedTokenAPIFunc implements EmbedTokenAPI.
+	EmbedTokenAPIFunc func() powerbi.EmbedTokenInterface
+
+	// GroupsAPIFunc implements GroupsAPI.
+	GroupsAPIFunc func() powerbi.GroupsInterface
+
+	// ProfilesAPIFunc implements ProfilesAPI.
+	ProfilesAPIFunc func() powerbi.ProfilesInterface
+
+	// PushDatasetsAPIFunc implements PushDatasetsAPI.
+	PushDatasetsAPIFunc func() powerbi.PushDatasetsInterface
+
+	// ReportsAPIFunc implements ReportsAPI.
+	ReportsAPIFunc func() powerbi.ReportsInterface
+}
+
+// AdminAPI records the call and calls AdminAPIFunc.
+func (m *ClientMock) AdminAPI() powerbi.Admin {
+	m.record("AdminAPI")
+	if m.AdminAPIFunc == nil {
+		panic("powerbimock: ClientMock.AdminAPI called but AdminAPIFunc is not set")
+	}
+	return m.AdminAPIFunc()
+}
+
+// DashboardsAPI records the call and calls DashboardsAPIFunc.
+func (m *ClientMock) DashboardsAPI() powerbi.DashboardsInterface {
+	m.record("DashboardsAPI")
+	if m.DashboardsAPIFunc == nil {
+		panic("powerbimock: ClientMock.DashboardsAPI called but DashboardsAPIFunc is not set")
+	}
+	return m.DashboardsAPIFunc()
+}
+
+// DatasetsAPI records the call and calls DatasetsAPIFunc.
+func (m *ClientMock) DatasetsAPI() powerbi.DatasetsInterface {
+	m.record("DatasetsAPI")
+	if m.DatasetsAPIFunc == nil {
+		panic("powerbimock: ClientMock.DatasetsAPI called but DatasetsAPIFunc is not set")
+	}
+	return m.DatasetsAPIFunc()
+}
+
+// EmbedTokenAPI records the call and calls EmbedTokenAPIFunc.
+func (m *ClientMock) EmbedTokenAPI() powerbi.EmbedTokenInterface {
+	m.record("EmbedTokenAPI")
+	if m.EmbedTokenAPIFunc == nil {
+		panic("powerbimock: ClientMock.EmbedTokenAPI called but EmbedTokenAPIFunc is not set")
+	}
+	return m.EmbedTokenAPIFunc()
+}
+
+// GroupsAPI records the call and calls GroupsAPIFunc.
+func (m *ClientMock) GroupsAPI() powerbi.GroupsInterface {
+	m.record("GroupsAPI")
+	if m.GroupsAPIFunc == nil {
+		panic("powerbimock: ClientMock.GroupsAPI called but GroupsAPIFunc is not set")
+	}
+	return m.GroupsAPIFunc()
+}
+
+// ProfilesAPI records the call and calls ProfilesAPIFunc.
+func (m *ClientMock) ProfilesAPI() powerbi.ProfilesInterface {
+	m.record("ProfilesAPI")
+	if m.ProfilesAPIFunc == nil {
+		panic("powerbimock: ClientMock.ProfilesAPI called but ProfilesAPIFunc is not set")
+	}
+	return m.ProfilesAPIFunc()
+}
+
+// PushDatasetsAPI records the call and calls PushDatasetsAPIFunc.
+func (m *ClientMock) PushDatasetsAPI() powerbi.PushDatasetsInterface {
+	m.record("PushDatasetsAPI")
+	if m.PushDatasetsAPIFunc == nil {
+		panic("powerbimock: ClientMock.PushDatasetsAPI called but PushDatasetsAPIFunc is not set")
+	}
+	return m.PushDatasetsAPIFunc()
+}
+
+// ReportsAPI records the call and calls ReportsAPIFunc.
+func (m *ClientMock) ReportsAPI() powerbi.ReportsInterface {
+	m.record("ReportsAPI")
+	if m.ReportsAPIFunc == nil {
+		panic("powerbimock: ClientMock.ReportsAPI called but ReportsAPIFunc is not set")
+	}
+	return m.ReportsAPIFunc()
+}
+
+var _ powerbi.Admin = &AdminMock{}
+
+// AdminMock is a mock implementation of powerbi.Admin.
+type AdminMock struct {
+	recorder
+
+	// GroupsFunc implements Groups.
+	GroupsFunc func() powerbi.Groups
+}
+
+// Groups records the call and calls GroupsFunc.
+func (m *AdminMock) Groups() powerbi.Groups {
+	m.record("Groups")
+	if m.GroupsFunc == nil {
+		panic("powerbimock: AdminMock.Groups called but GroupsFunc is not set")
+	}
+	return m.GroupsFunc()
+}
+
+var _ powerbi.Groups = &AdminGroupsMock{}
+
+// AdminGroupsMock is a mock implementation of powerbi.Groups.
+type AdminGroupsMock struct {
+	recorder
+
+	// AddUserAsAdminFunc implements AddUserAsAdmin.
+	AddUserAsAdminFunc func(context.Context, string, types.GroupUser) error
+
+	// AllGroupsAsAdminFunc implements AllGroupsAsAdmin.
+	AllGroupsAsAdminFunc func(context.Context, types.GroupsOptions) iter.Seq2[types.AdminGroup, error]
+
+	// AllUnusedArtifactsAsAdminFunc implements AllUnusedArtifactsAsAdmin.
+	AllUnusedArtifactsAsAdminFunc func(context.Context, string, types.UnusedArtifactsOptions) iter.Seq2[types.UnusedArtifactEntity, error]
+
+	// DeleteUserAsAdminFunc implements DeleteUserAsAdmin.
+	DeleteUserAsAdminFunc func(context.Context, string, string, types.DeleteUserOptions) error
+
+	// GetGroupAsAdminFunc implements GetGroupAsAdmin.
+	GetGroupAsAdminFunc func(context.Context, string, types.GroupOptions) (*types.AdminGroup, error)
+
+	// GetGroupUsersAsAdminFunc implements GetGroupUsersAsAdmin.
+	GetGroupUsersAsAdminFunc func(context.Context, string) ([]types.GroupUser, error)
+
+	// GetGroupsAsAdminFunc implements GetGroupsAsAdmin.
+	GetGroupsAsAdminFunc func(context.Context, types.GroupsOptions) ([]types.AdminGroup, error)
+
+	// GetUnusedArtifactsAsAdminFunc implements GetUnusedArtifactsAsAdmin.
+	GetUnusedArtifactsAsAdminFunc func(context.Context, string, types.UnusedArtifactsOptions) (*types.UnusedArtifactsResponse, error)
+
+	// RestoreDeletedGroupAsAdminFunc implements RestoreDeletedGroupAsAdmin.
+	RestoreDeletedGroupAsAdminFunc func(context.Context, string, types.GroupRestoreRequest) error
+
+	// UpdateGroupAsAdminFunc implements UpdateGroupAsAdmin.
+	UpdateGroupAsAdminFunc func(context.Context, string, types.AdminGroup) error
+}
+
+// AddUserAsAdmin records the call and calls AddUserAsAdminFunc.
+func (m *AdminGroupsMock) AddUserAsAdmin(ctx context.Context, groupID string, req types.GroupUser) error {
+	m.record("AddUserAsAdmin", ctx, groupID, req)
+	if m.AddUserAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.AddUserAsAdmin called but AddUserAsAdminFunc is not set")
+	}
+	return m.AddUserAsAdminFunc(ctx, groupID, req)
+}
+
+// AllGroupsAsAdmin records the call and calls AllGroupsAsAdminFunc.
+func (m *AdminGroupsMock) AllGroupsAsAdmin(ctx context.Context, opts types.GroupsOptions) iter.Seq2[types.AdminGroup, error] {
+	m.record("AllGroupsAsAdmin", ctx, opts)
+	if m.AllGroupsAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.AllGroupsAsAdmin called but AllGroupsAsAdminFunc is not set")
+	}
+	return m.AllGroupsAsAdminFunc(ctx, opts)
+}
+
+// AllUnusedArtifactsAsAdmin records the call and calls AllUnusedArtifactsAsAdminFunc.
+func (m *AdminGroupsMock) AllUnusedArtifactsAsAdmin(ctx context.Context, groupID string, opts types.UnusedArtifactsOptions) iter.Seq2[types.UnusedArtifactEntity, error] {
+	m.record("AllUnusedArtifactsAsAdmin", ctx, groupID, opts)
+	if m.AllUnusedArtifactsAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.AllUnusedArtifactsAsAdmin called but AllUnusedArtifactsAsAdminFunc is not set")
+	}
+	return m.AllUnusedArtifactsAsAdminFunc(ctx, groupID, opts)
+}
+
+// DeleteUserAsAdmin records the call and calls DeleteUserAsAdminFunc.
+func (m *AdminGroupsMock) DeleteUserAsAdmin(ctx context.Context, groupID string, userID string, opts types.DeleteUserOptions) error {
+	m.record("DeleteUserAsAdmin", ctx, groupID, userID, opts)
+	if m.DeleteUserAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.DeleteUserAsAdmin called but DeleteUserAsAdminFunc is not set")
+	}
+	return m.DeleteUserAsAdminFunc(ctx, groupID, userID, opts)
+}
+
+// GetGroupAsAdmin records the call and calls GetGroupAsAdminFunc.
+func (m *AdminGroupsMock) GetGroupAsAdmin(ctx context.Context, groupID string, opts types.GroupOptions) (*types.AdminGroup, error) {
+	m.record("GetGroupAsAdmin", ctx, groupID, opts)
+	if m.GetGroupAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.GetGroupAsAdmin called but GetGroupAsAdminFunc is not set")
+	}
+	return m.GetGroupAsAdminFunc(ctx, groupID, opts)
+}
+
+// GetGroupUsersAsAdmin records the call and calls GetGroupUsersAsAdminFunc.
+func (m *AdminGroupsMock) GetGroupUsersAsAdmin(ctx context.Context, groupID string) ([]types.GroupUser, error) {
+	m.record("GetGroupUsersAsAdmin", ctx, groupID)
+	if m.GetGroupUsersAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.GetGroupUsersAsAdmin called but GetGroupUsersAsAdminFunc is not set")
+	}
+	return m.GetGroupUsersAsAdminFunc(ctx, groupID)
+}
+
+// GetGroupsAsAdmin records the call and calls GetGroupsAsAdminFunc.
+func (m *AdminGroupsMock) GetGroupsAsAdmin(ctx context.Context, opts types.GroupsOptions) ([]types.AdminGroup, error) {
+	m.record("GetGroupsAsAdmin", ctx, opts)
+	if m.GetGroupsAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.GetGroupsAsAdmin called but GetGroupsAsAdminFunc is not set")
+	}
+	return m.GetGroupsAsAdminFunc(ctx, opts)
+}
+
+// GetUnusedArtifactsAsAdmin records the call and calls GetUnusedArtifactsAsAdminFunc.
+func (m *AdminGroupsMock) GetUnusedArtifactsAsAdmin(ctx context.Context, groupID string, opts types.UnusedArtifactsOptions) (*types.UnusedArtifactsResponse, error) {
+	m.record("GetUnusedArtifactsAsAdmin", ctx, groupID, opts)
+	if m.GetUnusedArtifactsAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.GetUnusedArtifactsAsAdmin called but GetUnusedArtifactsAsAdminFunc is not set")
+	}
+	return m.GetUnusedArtifactsAsAdminFunc(ctx, groupID, opts)
+}
+
+// RestoreDeletedGroupAsAdmin records the call and calls RestoreDeletedGroupAsAdminFunc.
+func (m *AdminGroupsMock) RestoreDeletedGroupAsAdmin(ctx context.Context, groupID string, req types.GroupRestoreRequest) error {
+	m.record("RestoreDeletedGroupAsAdmin", ctx, groupID, req)
+	if m.RestoreDeletedGroupAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.RestoreDeletedGroupAsAdmin called but RestoreDeletedGroupAsAdminFunc is not set")
+	}
+	return m.RestoreDeletedGroupAsAdminFunc(ctx, groupID, req)
+}
+
+// UpdateGroupAsAdmin records the call and calls UpdateGroupAsAdminFunc.
+func (m *AdminGroupsMock) UpdateGroupAsAdmin(ctx context.Context, groupID string, req types.AdminGroup) error {
+	m.record("UpdateGroupAsAdmin", ctx, groupID, req)
+	if m.UpdateGroupAsAdminFunc == nil {
+		panic("powerbimock: AdminGroupsMock.UpdateGroupAsAdmin called but UpdateGroupAsAdminFunc is not set")
+	}
+	return m.UpdateGroupAsAdminFunc(ctx, groupID, req)
+}
+
+var _ powerbi.DashboardsInterface = &DashboardsMock{}
+
+// DashboardsMock is a mock implementation of powerbi.DashboardsInterface.
+type DashboardsMock struct {
+	recorder
+
+	// AddFunc implements Add.
+	AddFunc func(context.Context, types.AddDashboardRequest) (*types.Dashboard, error)
+
+	// CloneTileFunc implements CloneTile.
+	CloneTileFunc func(context.Context, string, string, types.CloneTileRequest) (*types.Tile, error)
+
+	// DeleteFunc implements Delete.
+	DeleteFunc func(context.Context, string) error
+
+	// GetFunc implements Get.
+	GetFunc func(context.Context, string) (*types.Dashboard, error)
+
+	// GetTileFunc implements GetTile.
+	GetTileFunc func(context.Context, string, string) (*types.Tile, error)
+
+	// ListFunc implements List.
+	ListFunc func(context.Context) ([]types.Dashboard, error)
+
+	// ListTilesFunc implements ListTiles.
+	ListTilesFunc func(context.Context, string) ([]types.Tile, error)
+}
+
+// Add records the call and calls AddFunc.
+func (m *DashboardsMock) Add(ctx context.Context, req types.AddDashboardRequest) (*types.Dashboard, error) {
+	m.record("Add", ctx, req)
+	if m.AddFunc == nil {
+		panic("powerbimock: DashboardsMock.Add called but AddFunc is not set")
+	}
+	return m.AddFunc(ctx, req)
+}
+
+// CloneTile records the call and calls CloneTileFunc.
+func (m *DashboardsMock) CloneTile(ctx context.Context, dashboardID string, tileID string, req types.CloneTileRequest) (*types.Tile, error) {
+	m.record("CloneTile", ctx, dashboardID, tileID, req)
+	if m.CloneTileFunc == nil {
+		panic("powerbimock: DashboardsMock.CloneTile called but CloneTileFunc is not set")
+	}
+	return m.CloneTileFunc(ctx, dashboardID, tileID, req)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *DashboardsMock) Delete(ctx context.Context, dashboardID string) error {
+	m.record("Delete", ctx, dashboardID)
+	if m.DeleteFunc == nil {
+		panic("powerbimock: DashboardsMock.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(ctx, dashboardID)
+}
+
+// Get records the call and calls GetFunc.
+func (m *DashboardsMock) Get(ctx context.Context, dashboardID string) (*types.Dashboard, error) {
+	m.record("Get", ctx, dashboardID)
+	if m.GetFunc == nil {
+		panic("powerbimock: DashboardsMock.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(ctx, dashboardID)
+}
+
+// GetTile records the call and calls GetTileFunc.
+func (m *DashboardsMock) GetTile(ctx context.Context, dashboardID string, tileID string) (*types.Tile, error) {
+	m.record("GetTile", ctx, dashboardID, tileID)
+	if m.GetTileFunc == nil {
+		panic("powerbimock: DashboardsMock.GetTile called but GetTileFunc is not set")
+	}
+	return m.GetTileFunc(ctx, dashboardID, tileID)
+}
+
+// List records the call and calls ListFunc.
+func (m *DashboardsMock) List(ctx context.Context) ([]types.Dashboard, error) {
+	m.record("List", ctx)
+	if m.ListFunc == nil {
+		panic("powerbimock: DashboardsMock.List called but ListFunc is not set")
+	}
+	return m.ListFunc(ctx)
+}
+
+// ListTiles records the call and calls ListTilesFunc.
+func (m *DashboardsMock) ListTiles(ctx context.Context, dashboardID string) ([]types.Tile, error) {
+	m.record("ListTiles", ctx, dashboardID)
+	if m.ListTilesFunc == nil {
+		panic("powerbimock: DashboardsMock.ListTiles called but ListTilesFunc is not set")
+	}
+	return m.ListTilesFunc(ctx, dashboardID)
+}
+
+var _ powerbi.DatasetsInterface = &DatasetsMock{}
+
+// DatasetsMock is a mock implementation of powerbi.DatasetsInterface.
+type DatasetsMock struct {
+	recorder
+
+	// BindToGatewayFunc implements BindToGateway.
+	BindToGatewayFunc func(context.Context, string, types.BindToGatewayRequest) error
+
+	// CancelRefreshFunc implements CancelRefresh.
+	CancelRefreshFunc func(context.Context, string, string) error
+
+	// DatasetFunc implements Dataset.
+	DatasetFunc func(context.Context, string) (*types.Dataset, error)
+
+	// DatasetUsersFunc implements DatasetUsers.
+	DatasetUsersFunc func(context.Context, string) (*types.DatasetUsersAccess, error)
+
+	// DatasetsFunc implements Datasets.
+	DatasetsFunc func(context.Context) (*types.DatasetList, error)
+
+	// DatasourcesFunc implements Datasources.
+	DatasourcesFunc func(context.Context, string) (*types.DatasourceList, error)
+
+	// DeleteDatasetFunc implements DeleteDataset.
+	DeleteDatasetFunc func(context.Context, string) error
+
+	// DirectQueryRefreshScheduleFunc implements DirectQueryRefreshSchedule.
+	DirectQueryRefreshScheduleFunc func(context.Context, string) (*types.DirectQueryRefreshSchedule, error)
+
+	// DiscoverGatewaysFunc implements DiscoverGateways.
+	DiscoverGatewaysFunc func(context.Context, string) (*types.GatewayList, error)
+
+	// ExecuteQueriesFunc implements ExecuteQueries.
+	ExecuteQueriesFunc func(context.Context, string, types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error)
+
+	// GatewayDatasourcesFunc implements GatewayDatasources.
+	GatewayDatasourcesFunc func(context.Context, string) (*types.GatewayDatasourceList, error)
+
+	// GroupFunc implements Group.
+	GroupFunc func() powerbi.DatasetGroup
+
+	// ParametersFunc implements Parameters.
+	ParametersFunc func(context.Context, string) (*types.MashupParameterList, error)
+
+	// RefreshDatasetFunc implements RefreshDataset.
+	RefreshDatasetFunc func(context.Context, string, types.DatasetRefreshRequest) (string, error)
+
+	// RefreshExecutionDetailsFunc implements RefreshExecutionDetails.
+	RefreshExecutionDetailsFunc func(context.Context, string, string) (*types.DatasetRefreshDetail, error)
+
+	// RefreshHistoryFunc implements RefreshHistory.
+	RefreshHistoryFunc func(context.Context, string, types.RefreshHistoryOptions) (*types.RefreshList, error)
+
+	// RefreshScheduleFunc implements RefreshSchedule.
+	RefreshScheduleFunc func(context.Context, string) (*types.RefreshSchedule, error)
+
+	// SetAllConnectionsFunc implements SetAllConnections.
+	SetAllConnectionsFunc func(context.Context, string, types.SetAllConnectionsRequest) error
+
+	// UpdateDatasourcesFunc implements UpdateDatasources.
+	UpdateDatasourcesFunc func(context.Context, string, types.UpdateDatasourcesRequest) error
+
+	// UpdateDirectQueryRefreshScheduleFunc implements UpdateDirectQueryRefreshSchedule.
+	UpdateDirectQueryRefreshScheduleFunc func(context.Context, string, types.DirectQueryRefreshSchedule) error
+
+	// UpdateParametersFunc implements UpdateParameters.
+	UpdateParametersFunc func(context.Context, string, types.UpdateMashupParametersRequest) error
+
+	// UpdateRefreshScheduleFunc implements UpdateRefreshSchedule.
+	UpdateRefreshScheduleFunc func(context.Context, string, types.RefreshSchedule) error
+
+	// WaitForRefreshFunc implements WaitForRefresh.
+	WaitForRefreshFunc func(context.Context, string, string, powerbi.WaitOptions) (*types.Refresh, error)
+}
+
+// BindToGateway records the call and calls BindToGatewayFunc.
+func (m *DatasetsMock) BindToGateway(ctx context.Context, datasetID string, req types.BindToGatewayRequest) error {
+	m.record("BindToGateway", ctx, datasetID, req)
+	if m.BindToGatewayFunc == nil {
+		panic("powerbimock: DatasetsMock.BindToGateway called but BindToGatewayFunc is not set")
+	}
+	return m.BindToGatewayFunc(ctx, datasetID, req)
+}
+
+// CancelRefresh records the call and calls CancelRefreshFunc.
+func (m *DatasetsMock) CancelRefresh(ctx context.Context, datasetID string, refreshID string) error {
+	m.record("CancelRefresh", ctx, datasetID, refreshID)
+	if m.CancelRefreshFunc == nil {
+		panic("powerbimock: DatasetsMock.CancelRefresh called but CancelRefreshFunc is not set")
+	}
+	return m.CancelRefreshFunc(ctx, datasetID, refreshID)
+}
+
+// Dataset records the call and calls DatasetFunc.
+func (m *DatasetsMock) Dataset(ctx context.Context, datasetID string) (*types.Dataset, error) {
+	m.record("Dataset", ctx, datasetID)
+	if m.DatasetFunc == nil {
+		panic("powerbimock: DatasetsMock.Dataset called but DatasetFunc is not set")
+	}
+	return m.DatasetFunc(ctx, datasetID)
+}
+
+// DatasetUsers records the call and calls DatasetUsersFunc.
+func (m *DatasetsMock) DatasetUsers(ctx context.Context, datasetID string) (*types.DatasetUsersAccess, error) {
+	m.record("DatasetUsers", ctx, datasetID)
+	if m.DatasetUsersFunc == nil {
+		panic("powerbimock: DatasetsMock.DatasetUsers called but DatasetUsersFunc is not set")
+	}
+	return m.DatasetUsersFunc(ctx, datasetID)
+}
+
+// Datasets records the call and calls DatasetsFunc.
+func (m *DatasetsMock) Datasets(ctx context.Context) (*types.DatasetList, error) {
+	m.record("Datasets", ctx)
+	if m.DatasetsFunc == nil {
+		panic("powerbimock: DatasetsMock.Datasets called but DatasetsFunc is not set")
+	}
+	return m.DatasetsFunc(ctx)
+}
+
+// Datasources records the call and calls DatasourcesFunc.
+func (m *DatasetsMock) Datasources(ctx context.Context, datasetID string) (*types.DatasourceList, error) {
+	m.record("Datasources", ctx, datasetID)
+	if m.DatasourcesFunc == nil {
+		panic("powerbimock: DatasetsMock.Datasources called but DatasourcesFunc is not set")
+	}
+	return m.DatasourcesFunc(ctx, datasetID)
+}
+
+// DeleteDataset records the call and calls DeleteDatasetFunc.
+func (m *DatasetsMock) DeleteDataset(ctx context.Context, datasetID string) error {
+	m.record("DeleteDataset", ctx, datasetID)
+	if m.DeleteDatasetFunc == nil {
+		panic("powerbimock: DatasetsMock.DeleteDataset called but DeleteDatasetFunc is not set")
+	}
+	return m.DeleteDatasetFunc(ctx, datasetID)
+}
+
+// DirectQueryRefreshSchedule records the call and calls DirectQueryRefreshScheduleFunc.
+func (m *DatasetsMock) DirectQueryRefreshSchedule(ctx context.Context, datasetID string) (*types.DirectQueryRefreshSchedule, error) {
+	m.record("DirectQueryRefreshSchedule", ctx, datasetID)
+	if m.DirectQueryRefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetsMock.DirectQueryRefreshSchedule called but DirectQueryRefreshScheduleFunc is not set")
+	}
+	return m.DirectQueryRefreshScheduleFunc(ctx, datasetID)
+}
+
+// DiscoverGateways records the call and calls DiscoverGatewaysFunc.
+func (m *DatasetsMock) DiscoverGateways(ctx context.Context, datasetID string) (*types.GatewayList, error) {
+	m.record("DiscoverGateways", ctx, datasetID)
+	if m.DiscoverGatewaysFunc == nil {
+		panic("powerbimock: DatasetsMock.DiscoverGateways called but DiscoverGatewaysFunc is not set")
+	}
+	return m.DiscoverGatewaysFunc(ctx, datasetID)
+}
+
+// ExecuteQueries records the call and calls ExecuteQueriesFunc.
+func (m *DatasetsMock) ExecuteQueries(ctx context.Context, datasetID string, req types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error) {
+	m.record("ExecuteQueries", ctx, datasetID, req)
+	if m.ExecuteQueriesFunc == nil {
+		panic("powerbimock: DatasetsMock.ExecuteQueries called but ExecuteQueriesFunc is not set")
+	}
+	return m.ExecuteQueriesFunc(ctx, datasetID, req)
+}
+
+// GatewayDatasources records the call and calls GatewayDatasourcesFunc.
+func (m *DatasetsMock) GatewayDatasources(ctx context.Context, datasetID string) (*types.GatewayDatasourceList, error) {
+	m.record("GatewayDatasources", ctx, datasetID)
+	if m.GatewayDatasourcesFunc == nil {
+		panic("powerbimock: DatasetsMock.GatewayDatasources called but GatewayDatasourcesFunc is not set")
+	}
+	return m.GatewayDatasourcesFunc(ctx, datasetID)
+}
+
+// Group records the call and calls GroupFunc.
+func (m *DatasetsMock) Group() powerbi.DatasetGroup {
+	m.record("Group")
+	if m.GroupFunc == nil {
+		panic("powerbimock: DatasetsMock.Group called but GroupFunc is not set")
+	}
+	return m.GroupFunc()
+}
+
+// Parameters records the call and calls ParametersFunc.
+func (m *DatasetsMock) Parameters(ctx context.Context, datasetID string) (*types.MashupParameterList, error) {
+	m.record("Parameters", ctx, datasetID)
+	if m.ParametersFunc == nil {
+		panic("powerbimock: DatasetsMock.Parameters called but ParametersFunc is not set")
+	}
+	return m.ParametersFunc(ctx, datasetID)
+}
+
+// RefreshDataset records the call and calls RefreshDatasetFunc.
+func (m *DatasetsMock) RefreshDataset(ctx context.Context, datasetID string, req types.DatasetRefreshRequest) (string, error) {
+	m.record("RefreshDataset", ctx, datasetID, req)
+	if m.RefreshDatasetFunc == nil {
+		panic("powerbimock: DatasetsMock.RefreshDataset called but RefreshDatasetFunc is not set")
+	}
+	return m.RefreshDatasetFunc(ctx, datasetID, req)
+}
+
+// RefreshExecutionDetails records the call and calls RefreshExecutionDetailsFunc.
+func (m *DatasetsMock) RefreshExecutionDetails(ctx context.Context, datasetID string, refreshID string) (*types.DatasetRefreshDetail, error) {
+	m.record("RefreshExecutionDetails", ctx, datasetID, refreshID)
+	if m.RefreshExecutionDetailsFunc == nil {
+		panic("powerbimock: DatasetsMock.RefreshExecutionDetails called but RefreshExecutionDetailsFunc is not set")
+	}
+	return m.RefreshExecutionDetailsFunc(ctx, datasetID, refreshID)
+}
+
+// RefreshHistory records the call and calls RefreshHistoryFunc.
+func (m *DatasetsMock) RefreshHistory(ctx context.Context, datasetID string, opts types.RefreshHistoryOptions) (*types.RefreshList, error) {
+	m.record("RefreshHistory", ctx, datasetID, opts)
+	if m.RefreshHistoryFunc == nil {
+		panic("powerbimock: DatasetsMock.RefreshHistory called but RefreshHistoryFunc is not set")
+	}
+	return m.RefreshHistoryFunc(ctx, datasetID, opts)
+}
+
+// RefreshSchedule records the call and calls RefreshScheduleFunc.
+func (m *DatasetsMock) RefreshSchedule(ctx context.Context, datasetID string) (*types.RefreshSchedule, error) {
+	m.record("RefreshSchedule", ctx, datasetID)
+	if m.RefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetsMock.RefreshSchedule called but RefreshScheduleFunc is not set")
+	}
+	return m.RefreshScheduleFunc(ctx, datasetID)
+}
+
+// SetAllConnections records the call and calls SetAllConnectionsFunc.
+func (m *DatasetsMock) SetAllConnections(ctx context.Context, datasetID string, req types.SetAllConnectionsRequest) error {
+	m.record("SetAllConnections", ctx, datasetID, req)
+	if m.SetAllConnectionsFunc == nil {
+		panic("powerbimock: DatasetsMock.SetAllConnections called but SetAllConnectionsFunc is not set")
+	}
+	return m.SetAllConnectionsFunc(ctx, datasetID, req)
+}
+
+// UpdateDatasources records the call and calls UpdateDatasourcesFunc.
+func (m *DatasetsMock) UpdateDatasources(ctx context.Context, datasetID string, req types.UpdateDatasourcesRequest) error {
+	m.record("UpdateDatasources", ctx, datasetID, req)
+	if m.UpdateDatasourcesFunc == nil {
+		panic("powerbimock: DatasetsMock.UpdateDatasources called but UpdateDatasourcesFunc is not set")
+	}
+	return m.UpdateDatasourcesFunc(ctx, datasetID, req)
+}
+
+// UpdateDirectQueryRefreshSchedule records the call and calls UpdateDirectQueryRefreshScheduleFunc.
+func (m *DatasetsMock) UpdateDirectQueryRefreshSchedule(ctx context.Context, datasetID string, schedule types.DirectQueryRefreshSchedule) error {
+	m.record("UpdateDirectQueryRefreshSchedule", ctx, datasetID, schedule)
+	if m.UpdateDirectQueryRefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetsMock.UpdateDirectQueryRefreshSchedule called but UpdateDirectQueryRefreshScheduleFunc is not set")
+	}
+	return m.UpdateDirectQueryRefreshScheduleFunc(ctx, datasetID, schedule)
+}
+
+// UpdateParameters records the call and calls UpdateParametersFunc.
+func (m *DatasetsMock) UpdateParameters(ctx context.Context, datasetID string, req types.UpdateMashupParametersRequest) error {
+	m.record("UpdateParameters", ctx, datasetID, req)
+	if m.UpdateParametersFunc == nil {
+		panic("powerbimock: DatasetsMock.UpdateParameters called but UpdateParametersFunc is not set")
+	}
+	return m.UpdateParametersFunc(ctx, datasetID, req)
+}
+
+// UpdateRefreshSchedule records the call and calls UpdateRefreshScheduleFunc.
+func (m *DatasetsMock) UpdateRefreshSchedule(ctx context.Context, datasetID string, schedule types.RefreshSchedule) error {
+	m.record("UpdateRefreshSchedule", ctx, datasetID, schedule)
+	if m.UpdateRefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetsMock.UpdateRefreshSchedule called but UpdateRefreshScheduleFunc is not set")
+	}
+	return m.UpdateRefreshScheduleFunc(ctx, datasetID, schedule)
+}
+
+// WaitForRefresh records the call and calls WaitForRefreshFunc.
+func (m *DatasetsMock) WaitForRefresh(ctx context.Context, datasetID string, refreshID string, opts powerbi.WaitOptions) (*types.Refresh, error) {
+	m.record("WaitForRefresh", ctx, datasetID, refreshID, opts)
+	if m.WaitForRefreshFunc == nil {
+		panic("powerbimock: DatasetsMock.WaitForRefresh called but WaitForRefreshFunc is not set")
+	}
+	return m.WaitForRefreshFunc(ctx, datasetID, refreshID, opts)
+}
+
+var _ powerbi.DatasetGroup = &DatasetGroupMock{}
+
+// DatasetGroupMock is a mock implementation of powerbi.DatasetGroup.
+type DatasetGroupMock struct {
+	recorder
+
+	// BindToGatewayFunc implements BindToGateway.
+	BindToGatewayFunc func(context.Context, string, string, types.BindToGatewayRequest) error
+
+	// CancelRefreshFunc implements CancelRefresh.
+	CancelRefreshFunc func(context.Context, string, string, string) error
+
+	// DatasetFunc implements Dataset.
+	DatasetFunc func(context.Context, string, string) (*types.Dataset, error)
+
+	// DatasetToDataflowLinksFunc implements DatasetToDataflowLinks.
+	DatasetToDataflowLinksFunc func(context.Context, string) (*types.DatasetToDataflowLinksResponse, error)
+
+	// DatasetUsersFunc implements DatasetUsers.
+	DatasetUsersFunc func(context.Context, string, string) (*types.DatasetUsersAccess, error)
+
+	// DatasetsFunc implements Datasets.
+	DatasetsFunc func(context.Context, string) (*types.DatasetList, error)
+
+	// DatasourcesFunc implements Datasources.
+	DatasourcesFunc func(context.Context, string, string) (*types.DatasourceList, error)
+
+	// DeleteDatasetFunc implements DeleteDataset.
+	DeleteDatasetFunc func(context.Context, string, string) error
+
+	// DirectQueryRefreshScheduleFunc implements DirectQueryRefreshSchedule.
+	DirectQueryRefreshScheduleFunc func(context.Context, string, string) (*types.DirectQueryRefreshSchedule, error)
+
+	// DiscoverGatewaysFunc implements DiscoverGateways.
+	DiscoverGatewaysFunc func(context.Context, string, string) (*types.GatewayList, error)
+
+	// ExecuteQueriesFunc implements ExecuteQueries.
+	ExecuteQueriesFunc func(context.Context, string, string, types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error)
+
+	// GatewayDatasourcesFunc implements GatewayDatasources.
+	GatewayDatasourcesFunc func(context.Context, string, string) (*types.GatewayDatasourceList, error)
+
+	// ParametersFunc implements Parameters.
+	ParametersFunc func(context.Context, string, string) (*types.MashupParameterList, error)
+
+	// QueryRowsFunc implements QueryRows.
+	QueryRowsFunc func(context.Context, string, string, powerbi.WindowedQuery) iter.Seq2[map[string]any, error]
+
+	// RefreshDatasetFunc implements RefreshDataset.
+	RefreshDatasetFunc func(context.Context, string, string, types.DatasetRefreshRequest) (string, error)
+
+	// RefreshExecutionDetailsFunc implements RefreshExecutionDetails.
+	RefreshExecutionDetailsFunc func(context.Context, string, string, string) (*types.DatasetRefreshDetail, error)
+
+	// RefreshHistoryFunc implements RefreshHistory.
+	RefreshHistoryFunc func(context.Context, string, string, types.RefreshHistoryOptions) (*types.RefreshList, error)
+
+	// RefreshScheduleFunc implements RefreshSchedule.
+	RefreshScheduleFunc func(context.Context, string, string) (*types.RefreshSchedule, error)
+
+	// SetAllConnectionsFunc implements SetAllConnections.
+	SetAllConnectionsFunc func(context.Context, string, string, types.SetAllConnectionsRequest) error
+
+	// TakeOverFunc implements TakeOver.
+	TakeOverFunc func(context.Context, string, string) error
+
+	// UpdateDatasourcesFunc implements UpdateDatasources.
+	UpdateDatasourcesFunc func(context.Context, string, string, types.UpdateDatasourcesRequest) error
+
+	// UpdateDirectQueryRefreshScheduleFunc implements UpdateDirectQueryRefreshSchedule.
+	UpdateDirectQueryRefreshScheduleFunc func(context.Context, string, string, types.DirectQueryRefreshSchedule) error
+
+	// UpdateParametersFunc implements UpdateParameters.
+	UpdateParametersFunc func(context.Context, string, string, types.UpdateMashupParametersRequest) error
+
+	// UpdateRefreshScheduleFunc implements UpdateRefreshSchedule.
+	UpdateRefreshScheduleFunc func(context.Context, string, string, types.RefreshSchedule) error
+
+	// WaitForRefreshFunc implements WaitForRefresh.
+	WaitForRefreshFunc func(context.Context, string, string, string, powerbi.WaitOptions) (*types.Refresh, error)
+}
+
+// BindToGateway records the call and calls BindToGatewayFunc.
+func (m *DatasetGroupMock) BindToGateway(ctx context.Context, groupID string, datasetID string, req types.BindToGatewayRequest) error {
+	m.record("BindToGateway", ctx, groupID, datasetID, req)
+	if m.BindToGatewayFunc == nil {
+		panic("powerbimock: DatasetGroupMock.BindToGateway called but BindToGatewayFunc is not set")
+	}
+	return m.BindToGatewayFunc(ctx, groupID, datasetID, req)
+}
+
+// CancelRefresh records the call and calls CancelRefreshFunc.
+func (m *DatasetGroupMock) CancelRefresh(ctx context.Context, groupID string, datasetID string, refreshID string) error {
+	m.record("CancelRefresh", ctx, groupID, datasetID, refreshID)
+	if m.CancelRefreshFunc == nil {
+		panic("powerbimock: DatasetGroupMock.CancelRefresh called but CancelRefreshFunc is not set")
+	}
+	return m.CancelRefreshFunc(ctx, groupID, datasetID, refreshID)
+}
+
+// Dataset records the call and calls DatasetFunc.
+func (m *DatasetGroupMock) Dataset(ctx context.Context, groupID string, datasetID string) (*types.Dataset, error) {
+	m.record("Dataset", ctx, groupID, datasetID)
+	if m.DatasetFunc == nil {
+		panic("powerbimock: DatasetGroupMock.Dataset called but DatasetFunc is not set")
+	}
+	return m.DatasetFunc(ctx, groupID, datasetID)
+}
+
+// DatasetToDataflowLinks records the call and calls DatasetToDataflowLinksFunc.
+func (m *DatasetGroupMock) DatasetToDataflowLinks(ctx context.Context, groupID string) (*types.DatasetToDataflowLinksResponse, error) {
+	m.record("DatasetToDataflowLinks", ctx, groupID)
+	if m.DatasetToDataflowLinksFunc == nil {
+		panic("powerbimock: DatasetGroupMock.DatasetToDataflowLinks called but DatasetToDataflowLinksFunc is not set")
+	}
+	return m.DatasetToDataflowLinksFunc(ctx, groupID)
+}
+
+// DatasetUsers records the call and calls DatasetUsersFunc.
+func (m *DatasetGroupMock) DatasetUsers(ctx context.Context, groupID string, datasetID string) (*types.DatasetUsersAccess, error) {
+	m.record("DatasetUsers", ctx, groupID, datasetID)
+	if m.DatasetUsersFunc == nil {
+		panic("powerbimock: DatasetGroupMock.DatasetUsers called but DatasetUsersFunc is not set")
+	}
+	return m.DatasetUsersFunc(ctx, groupID, datasetID)
+}
+
+// Datasets records the call and calls DatasetsFunc.
+func (m *DatasetGroupMock) Datasets(ctx context.Context, groupID string) (*types.DatasetList, error) {
+	m.record("Datasets", ctx, groupID)
+	if m.DatasetsFunc == nil {
+		panic("powerbimock: DatasetGroupMock.Datasets called but DatasetsFunc is not set")
+	}
+	return m.DatasetsFunc(ctx, groupID)
+}
+
+// Datasources records the call and calls DatasourcesFunc.
+func (m *DatasetGroupMock) Datasources(ctx context.Context, groupID string, datasetID string) (*types.DatasourceList, error) {
+	m.record("Datasources", ctx, groupID, datasetID)
+	if m.DatasourcesFunc == nil {
+		panic("powerbimock: DatasetGroupMock.Datasources called but DatasourcesFunc is not set")
+	}
+	return m.DatasourcesFunc(ctx, groupID, datasetID)
+}
+
+// DeleteDataset records the call and calls DeleteDatasetFunc.
+func (m *DatasetGroupMock) DeleteDataset(ctx context.Context, groupID string, datasetID string) error {
+	m.record("DeleteDataset", ctx, groupID, datasetID)
+	if m.DeleteDatasetFunc == nil {
+		panic("powerbimock: DatasetGroupMock.DeleteDataset called but DeleteDatasetFunc is not set")
+	}
+	return m.DeleteDatasetFunc(ctx, groupID, datasetID)
+}
+
+// DirectQueryRefreshSchedule records the call and calls DirectQueryRefreshScheduleFunc.
+func (m *DatasetGroupMock) DirectQueryRefreshSchedule(ctx context.Context, groupID string, datasetID string) (*types.DirectQueryRefreshSchedule, error) {
+	m.record("DirectQueryRefreshSchedule", ctx, groupID, datasetID)
+	if m.DirectQueryRefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetGroupMock.DirectQueryRefreshSchedule called but DirectQueryRefreshScheduleFunc is not set")
+	}
+	return m.DirectQueryRefreshScheduleFunc(ctx, groupID, datasetID)
+}
+
+// DiscoverGateways records the call and calls DiscoverGatewaysFunc.
+func (m *DatasetGroupMock) DiscoverGateways(ctx context.Context, groupID string, datasetID string) (*types.GatewayList, error) {
+	m.record("DiscoverGateways", ctx, groupID, datasetID)
+	if m.DiscoverGatewaysFunc == nil {
+		panic("powerbimock: DatasetGroupMock.DiscoverGateways called but DiscoverGatewaysFunc is not set")
+	}
+	return m.DiscoverGatewaysFunc(ctx, groupID, datasetID)
+}
+
+// ExecuteQueries records the call and calls ExecuteQueriesFunc.
+func (m *DatasetGroupMock) ExecuteQueries(ctx context.Context, groupID string, datasetID string, req types.DatasetExecuteQueriesRequest) (*types.DatasetExecuteQueriesResponse, error) {
+	m.record("ExecuteQueries", ctx, groupID, datasetID, req)
+	if m.ExecuteQueriesFunc == nil {
+		panic("powerbimock: DatasetGroupMock.ExecuteQueries called but ExecuteQueriesFunc is not set")
+	}
+	return m.ExecuteQueriesFunc(ctx, groupID, datasetID, req)
+}
+
+// GatewayDatasources records the call and calls GatewayDatasourcesFunc.
+func (m *DatasetGroupMock) GatewayDatasources(ctx context.Context, groupID string, datasetID string) (*types.GatewayDatasourceList, error) {
+	m.record("GatewayDatasources", ctx, groupID, datasetID)
+	if m.GatewayDatasourcesFunc == nil {
+		panic("powerbimock: DatasetGroupMock.GatewayDatasources called but GatewayDatasourcesFunc is not set")
+	}
+	return m.GatewayDatasourcesFunc(ctx, groupID, datasetID)
+}
+
+// Parameters records the call and calls ParametersFunc.
+func (m *DatasetGroupMock) Parameters(ctx context.Context, groupID string, datasetID string) (*types.MashupParameterList, error) {
+	m.record("Parameters", ctx, groupID, datasetID)
+	if m.ParametersFunc == nil {
+		panic("powerbimock: DatasetGroupMock.Parameters called but ParametersFunc is not set")
+	}
+	return m.ParametersFunc(ctx, groupID, datasetID)
+}
+
+// QueryRows records the call and calls QueryRowsFunc.
+func (m *DatasetGroupMock) QueryRows(ctx context.Context, groupID string, datasetID string, q powerbi.WindowedQuery) iter.Seq2[map[string]any, error] {
+	m.record("QueryRows", ctx, groupID, datasetID, q)
+	if m.QueryRowsFunc == nil {
+		panic("powerbimock: DatasetGroupMock.QueryRows called but QueryRowsFunc is not set")
+	}
+	return m.QueryRowsFunc(ctx, groupID, datasetID, q)
+}
+
+// RefreshDataset records the call and calls RefreshDatasetFunc.
+func (m *DatasetGroupMock) RefreshDataset(ctx context.Context, groupID string, datasetID string, req types.DatasetRefreshRequest) (string, error) {
+	m.record("RefreshDataset", ctx, groupID, datasetID, req)
+	if m.RefreshDatasetFunc == nil {
+		panic("powerbimock: DatasetGroupMock.RefreshDataset called but RefreshDatasetFunc is not set")
+	}
+	return m.RefreshDatasetFunc(ctx, groupID, datasetID, req)
+}
+
+// RefreshExecutionDetails records the call and calls RefreshExecutionDetailsFunc.
+func (m *DatasetGroupMock) RefreshExecutionDetails(ctx context.Context, groupID string, datasetID string, refreshID string) (*types.DatasetRefreshDetail, error) {
+	m.record("RefreshExecutionDetails", ctx, groupID, datasetID, refreshID)
+	if m.RefreshExecutionDetailsFunc == nil {
+		panic("powerbimock: DatasetGroupMock.RefreshExecutionDetails called but RefreshExecutionDetailsFunc is not set")
+	}
+	return m.RefreshExecutionDetailsFunc(ctx, groupID, datasetID, refreshID)
+}
+
+// RefreshHistory records the call and calls RefreshHistoryFunc.
+func (m *DatasetGroupMock) RefreshHistory(ctx context.Context, groupID string, datasetID string, opts types.RefreshHistoryOptions) (*types.RefreshList, error) {
+	m.record("RefreshHistory", ctx, groupID, datasetID, opts)
+	if m.RefreshHistoryFunc == nil {
+		panic("powerbimock: DatasetGroupMock.RefreshHistory called but RefreshHistoryFunc is not set")
+	}
+	return m.RefreshHistoryFunc(ctx, groupID, datasetID, opts)
+}
+
+// RefreshSchedule records the call and calls RefreshScheduleFunc.
+func (m *DatasetGroupMock) RefreshSchedule(ctx context.Context, groupID string, datasetID string) (*types.RefreshSchedule, error) {
+	m.record("RefreshSchedule", ctx, groupID, datasetID)
+	if m.RefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetGroupMock.RefreshSchedule called but RefreshScheduleFunc is not set")
+	}
+	return m.RefreshScheduleFunc(ctx, groupID, datasetID)
+}
+
+// SetAllConnections records the call and calls SetAllConnectionsFunc.
+func (m *DatasetGroupMock) SetAllConnections(ctx context.Context, groupID string, datasetID string, req types.SetAllConnectionsRequest) error {
+	m.record("SetAllConnections", ctx, groupID, datasetID, req)
+	if m.SetAllConnectionsFunc == nil {
+		panic("powerbimock: DatasetGroupMock.SetAllConnections called but SetAllConnectionsFunc is not set")
+	}
+	return m.SetAllConnectionsFunc(ctx, groupID, datasetID, req)
+}
+
+// TakeOver records the call and calls TakeOverFunc.
+func (m *DatasetGroupMock) TakeOver(ctx context.Context, groupID string, datasetID string) error {
+	m.record("TakeOver", ctx, groupID, datasetID)
+	if m.TakeOverFunc == nil {
+		panic("powerbimock: DatasetGroupMock.TakeOver called but TakeOverFunc is not set")
+	}
+	return m.TakeOverFunc(ctx, groupID, datasetID)
+}
+
+// UpdateDatasources records the call and calls UpdateDatasourcesFunc.
+func (m *DatasetGroupMock) UpdateDatasources(ctx context.Context, groupID string, datasetID string, req types.UpdateDatasourcesRequest) error {
+	m.record("UpdateDatasources", ctx, groupID, datasetID, req)
+	if m.UpdateDatasourcesFunc == nil {
+		panic("powerbimock: DatasetGroupMock.UpdateDatasources called but UpdateDatasourcesFunc is not set")
+	}
+	return m.UpdateDatasourcesFunc(ctx, groupID, datasetID, req)
+}
+
+// UpdateDirectQueryRefreshSchedule records the call and calls UpdateDirectQueryRefreshScheduleFunc.
+func (m *DatasetGroupMock) UpdateDirectQueryRefreshSchedule(ctx context.Context, groupID string, datasetID string, schedule types.DirectQueryRefreshSchedule) error {
+	m.record("UpdateDirectQueryRefreshSchedule", ctx, groupID, datasetID, schedule)
+	if m.UpdateDirectQueryRefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetGroupMock.UpdateDirectQueryRefreshSchedule called but UpdateDirectQueryRefreshScheduleFunc is not set")
+	}
+	return m.UpdateDirectQueryRefreshScheduleFunc(ctx, groupID, datasetID, schedule)
+}
+
+// UpdateParameters records the call and calls UpdateParametersFunc.
+func (m *DatasetGroupMock) UpdateParameters(ctx context.Context, groupID string, datasetID string, req types.UpdateMashupParametersRequest) error {
+	m.record("UpdateParameters", ctx, groupID, datasetID, req)
+	if m.UpdateParametersFunc == nil {
+		panic("powerbimock: DatasetGroupMock.UpdateParameters called but UpdateParametersFunc is not set")
+	}
+	return m.UpdateParametersFunc(ctx, groupID, datasetID, req)
+}
+
+// UpdateRefreshSchedule records the call and calls UpdateRefreshScheduleFunc.
+func (m *DatasetGroupMock) UpdateRefreshSchedule(ctx context.Context, groupID string, datasetID string, schedule types.RefreshSchedule) error {
+	m.record("UpdateRefreshSchedule", ctx, groupID, datasetID, schedule)
+	if m.UpdateRefreshScheduleFunc == nil {
+		panic("powerbimock: DatasetGroupMock.UpdateRefreshSchedule called but UpdateRefreshScheduleFunc is not set")
+	}
+	return m.UpdateRefreshScheduleFunc(ctx, groupID, datasetID, schedule)
+}
+
+// WaitForRefresh records the call and calls WaitForRefreshFunc.
+func (m *DatasetGroupMock) WaitForRefresh(ctx context.Context, groupID string, datasetID string, refreshID string, opts powerbi.WaitOptions) (*types.Refresh, error) {
+	m.record("WaitForRefresh", ctx, groupID, datasetID, refreshID, opts)
+	if m.WaitForRefreshFunc == nil {
+		panic("powerbimock: DatasetGroupMock.WaitForRefresh called but WaitForRefreshFunc is not set")
+	}
+	return m.WaitForRefreshFunc(ctx, groupID, datasetID, refreshID, opts)
+}
+
+var _ powerbi.EmbedTokenInterface = &EmbedTokenMock{}
+
+// EmbedTokenMock is a mock implementation of powerbi.EmbedTokenInterface.
+type EmbedTokenMock struct {
+	recorder
+
+	// GenerateTokenFunc implements GenerateToken.
+	GenerateTokenFunc func(context.Context, types.GenerateTokenRequestV2) (*types.EmbedToken, error)
+
+	// GenerateTokenForDashboardsInGroupFunc implements GenerateTokenForDashboardsInGroup.
+	GenerateTokenForDashboardsInGroupFunc func(context.Context, string, string, types.GenerateTokenRequest) (*types.EmbedToken, error)
+
+	// GenerateTokenForDatasetsInGroupFunc implements GenerateTokenForDatasetsInGroup.
+	GenerateTokenForDatasetsInGroupFunc func(context.Context, string, string, types.GenerateTokenRequest) (*types.EmbedToken, error)
+
+	// GenerateTokenForReportsCreateInGroupFunc implements GenerateTokenForReportsCreateInGroup.
+	GenerateTokenForReportsCreateInGroupFunc func(context.Context, string, types.GenerateTokenRequest) (*types.EmbedToken, error)
+
+	// GenerateTokenForReportsInGroupFunc implements GenerateTokenForReportsInGroup.
+	GenerateTokenForReportsInGroupFunc func(context.Context, string, string, types.GenerateTokenRequest) (*types.EmbedToken, error)
+
+	// GenerateTokenForTilesInGroupFunc implements GenerateTokenForTilesInGroup.
+	GenerateTokenForTilesInGroupFunc func(context.Context, string, string, string, types.GenerateTokenRequest) (*types.EmbedToken, error)
+}
+
+// GenerateToken records the call and calls GenerateTokenFunc.
+func (m *EmbedTokenMock) GenerateToken(ctx context.Context, req types.GenerateTokenRequestV2) (*types.EmbedToken, error) {
+	m.record("GenerateToken", ctx, req)
+	if m.GenerateTokenFunc == nil {
+		panic("powerbimock: EmbedTokenMock.GenerateToken called but GenerateTokenFunc is not set")
+	}
+	return m.GenerateTokenFunc(ctx, req)
+}
+
+// GenerateTokenForDashboardsInGroup records the call and calls GenerateTokenForDashboardsInGroupFunc.
+func (m *EmbedTokenMock) GenerateTokenForDashboardsInGroup(ctx context.Context, groupID string, dashboardID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	m.record("GenerateTokenForDashboardsInGroup", ctx, groupID, dashboardID, req)
+	if m.GenerateTokenForDashboardsInGroupFunc == nil {
+		panic("powerbimock: EmbedTokenMock.GenerateTokenForDashboardsInGroup called but GenerateTokenForDashboardsInGroupFunc is not set")
+	}
+	return m.GenerateTokenForDashboardsInGroupFunc(ctx, groupID, dashboardID, req)
+}
+
+// GenerateTokenForDatasetsInGroup records the call and calls GenerateTokenForDatasetsInGroupFunc.
+func (m *EmbedTokenMock) GenerateTokenForDatasetsInGroup(ctx context.Context, groupID string, datasetID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	m.record("GenerateTokenForDatasetsInGroup", ctx, groupID, datasetID, req)
+	if m.GenerateTokenForDatasetsInGroupFunc == nil {
+		panic("powerbimock: EmbedTokenMock.GenerateTokenForDatasetsInGroup called but GenerateTokenForDatasetsInGroupFunc is not set")
+	}
+	return m.GenerateTokenForDatasetsInGroupFunc(ctx, groupID, datasetID, req)
+}
+
+// GenerateTokenForReportsCreateInGroup records the call and calls GenerateTokenForReportsCreateInGroupFunc.
+func (m *EmbedTokenMock) GenerateTokenForReportsCreateInGroup(ctx context.Context, groupID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	m.record("GenerateTokenForReportsCreateInGroup", ctx, groupID, req)
+	if m.GenerateTokenForReportsCreateInGroupFunc == nil {
+		panic("powerbimock: EmbedTokenMock.GenerateTokenForReportsCreateInGroup called but GenerateTokenForReportsCreateInGroupFunc is not set")
+	}
+	return m.GenerateTokenForReportsCreateInGroupFunc(ctx, groupID, req)
+}
+
+// GenerateTokenForReportsInGroup records the call and calls GenerateTokenForReportsInGroupFunc.
+func (m *EmbedTokenMock) GenerateTokenForReportsInGroup(ctx context.Context, groupID string, reportID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	m.record("GenerateTokenForReportsInGroup", ctx, groupID, reportID, req)
+	if m.GenerateTokenForReportsInGroupFunc == nil {
+		panic("powerbimock: EmbedTokenMock.GenerateTokenForReportsInGroup called but GenerateTokenForReportsInGroupFunc is not set")
+	}
+	return m.GenerateTokenForReportsInGroupFunc(ctx, groupID, reportID, req)
+}
+
+// GenerateTokenForTilesInGroup records the call and calls GenerateTokenForTilesInGroupFunc.
+func (m *EmbedTokenMock) GenerateTokenForTilesInGroup(ctx context.Context, groupID string, dashboardID string, tileID string, req types.GenerateTokenRequest) (*types.EmbedToken, error) {
+	m.record("GenerateTokenForTilesInGroup", ctx, groupID, dashboardID, tileID, req)
+	if m.GenerateTokenForTilesInGroupFunc == nil {
+		panic("powerbimock: EmbedTokenMock.GenerateTokenForTilesInGroup called but GenerateTokenForTilesInGroupFunc is not set")
+	}
+	return m.GenerateTokenForTilesInGroupFunc(ctx, groupID, dashboardID, tileID, req)
+}
+
+var _ powerbi.GroupsInterface = &GroupsMock{}
+
+// GroupsMock is a mock implementation of powerbi.GroupsInterface.
+type GroupsMock struct {
+	recorder
+
+	// AddDashboardFunc implements AddDashboard.
+	AddDashboardFunc func(context.Context, string, types.AddDashboardRequest) (*types.Dashboard, error)
+
+	// AddGroupUserFunc implements AddGroupUser.
+	AddGroupUserFunc func(context.Context, string, types.GroupUser) error
+
+	// AllFunc implements All.
+	AllFunc func(context.Context, types.ListGroupsOptions) iter.Seq2[types.Group, error]
+
+	// AllGroupUsersFunc implements AllGroupUsers.
+	AllGroupUsersFunc func(context.Context, string, types.ListGroupUserOptions) iter.Seq2[types.GroupUser, error]
+
+	// CloneTileFunc implements CloneTile.
+	CloneTileFunc func(context.Context, string, string, string, types.CloneTileRequest) (*types.Tile, error)
+
+	// CreateFunc implements Create.
+	CreateFunc func(context.Context, types.CreateGroupRequest, types.CreateGroupOptions) (*types.Group, error)
+
+	// DeleteFunc implements Delete.
+	DeleteFunc func(context.Context, string) error
+
+	// DeleteDashboardFunc implements DeleteDashboard.
+	DeleteDashboardFunc func(context.Context, string, string) error
+
+	// DeleteGroupUserFunc implements DeleteGroupUser.
+	DeleteGroupUserFunc func(context.Context, string, string, types.DeleteGroupUserOptions) error
+
+	// GetFunc implements Get.
+	GetFunc func(context.Context, string) (*types.Group, error)
+
+	// GetDashboardFunc implements GetDashboard.
+	GetDashboardFunc func(context.Context, string, string) (*types.Dashboard, error)
+
+	// GetTileFunc implements GetTile.
+	GetTileFunc func(context.Context, string, string, string) (*types.Tile, error)
+
+	// ListFunc implements List.
+	ListFunc func(context.Context, types.ListGroupsOptions) ([]types.Group, error)
+
+	// ListDashboardsFunc implements ListDashboards.
+	ListDashboardsFunc func(context.Context, string) ([]types.Dashboard, error)
+
+	// ListGroupUsersFunc implements ListGroupUsers.
+	ListGroupUsersFunc func(context.Context, string, types.ListGroupUserOptions) ([]types.GroupUser, error)
+
+	// ListTilesFunc implements ListTiles.
+	ListTilesFunc func(context.Context, string, string) ([]types.Tile, error)
+
+	// UpdateFunc implements Update.
+	UpdateFunc func(context.Context, string, types.UpdateGroupRequest) (*types.Group, error)
+
+	// UpdateGroupUserFunc implements UpdateGroupUser.
+	UpdateGroupUserFunc func(context.Context, string, types.GroupUser) error
+}
+
+// AddDashboard records the call and calls AddDashboardFunc.
+func (m *GroupsMock) AddDashboard(ctx context.Context, groupID string, req types.AddDashboardRequest) (*types.Dashboard, error) {
+	m.record("AddDashboard", ctx, groupID, req)
+	if m.AddDashboardFunc == nil {
+		panic("powerbimock: GroupsMock.AddDashboard called but AddDashboardFunc is not set")
+	}
+	return m.AddDashboardFunc(ctx, groupID, req)
+}
+
+// AddGroupUser records the call and calls AddGroupUserFunc.
+func (m *GroupsMock) AddGroupUser(ctx context.Context, groupID string, user types.GroupUser) error {
+	m.record("AddGroupUser", ctx, groupID, user)
+	if m.AddGroupUserFunc == nil {
+		panic("powerbimock: GroupsMock.AddGroupUser called but AddGroupUserFunc is not set")
+	}
+	return m.AddGroupUserFunc(ctx, groupID, user)
+}
+
+// All records the call and calls AllFunc.
+func (m *GroupsMock) All(ctx context.Context, opts types.ListGroupsOptions) iter.Seq2[types.Group, error] {
+	m.record("All", ctx, opts)
+	if m.AllFunc == nil {
+		panic("powerbimock: GroupsMock.All called but AllFunc is not set")
+	}
+	return m.AllFunc(ctx, opts)
+}
+
+// AllGroupUsers records the call and calls AllGroupUsersFunc.
+func (m *GroupsMock) AllGroupUsers(ctx context.Context, groupID string, opts types.ListGroupUserOptions) iter.Seq2[types.GroupUser, error] {
+	m.record("AllGroupUsers", ctx, groupID, opts)
+	if m.AllGroupUsersFunc == nil {
+		panic("powerbimock: GroupsMock.AllGroupUsers called but AllGroupUsersFunc is not set")
+	}
+	return m.AllGroupUsersFunc(ctx, groupID, opts)
+}
+
+// CloneTile records the call and calls CloneTileFunc.
+func (m *GroupsMock) CloneTile(ctx context.Context, groupID string, dashboardID string, tileID string, req types.CloneTileRequest) (*types.Tile, error) {
+	m.record("CloneTile", ctx, groupID, dashboardID, tileID, req)
+	if m.CloneTileFunc == nil {
+		panic("powerbimock: GroupsMock.CloneTile called but CloneTileFunc is not set")
+	}
+	return m.CloneTileFunc(ctx, groupID, dashboardID, tileID, req)
+}
+
+// Create records the call and calls CreateFunc.
+func (m *GroupsMock) Create(ctx context.Context, req types.CreateGroupRequest, opts types.CreateGroupOptions) (*types.Group, error) {
+	m.record("Create", ctx, req, opts)
+	if m.CreateFunc == nil {
+		panic("powerbimock: GroupsMock.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(ctx, req, opts)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *GroupsMock) Delete(ctx context.Context, groupID string) error {
+	m.record("Delete", ctx, groupID)
+	if m.DeleteFunc == nil {
+		panic("powerbimock: GroupsMock.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(ctx, groupID)
+}
+
+// DeleteDashboard records the call and calls DeleteDashboardFunc.
+func (m *GroupsMock) DeleteDashboard(ctx context.Context, groupID string, dashboardID string) error {
+	m.record("DeleteDashboard", ctx, groupID, dashboardID)
+	if m.DeleteDashboardFunc == nil {
+		panic("powerbimock: GroupsMock.DeleteDashboard called but DeleteDashboardFunc is not set")
+	}
+	return m.DeleteDashboardFunc(ctx, groupID, dashboardID)
+}
+
+// DeleteGroupUser records the call and calls DeleteGroupUserFunc.
+func (m *GroupsMock) DeleteGroupUser(ctx context.Context, groupID string, user string, opts types.DeleteGroupUserOptions) error {
+	m.record("DeleteGroupUser", ctx, groupID, user, opts)
+	if m.DeleteGroupUserFunc == nil {
+		panic("powerbimock: GroupsMock.DeleteGroupUser called but DeleteGroupUserFunc is not set")
+	}
+	return m.DeleteGroupUserFunc(ctx, groupID, user, opts)
+}
+
+// Get records the call and calls GetFunc.
+func (m *GroupsMock) Get(ctx context.Context, groupID string) (*types.Group, error) {
+	m.record("Get", ctx, groupID)
+	if m.GetFunc == nil {
+		panic("powerbimock: GroupsMock.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(ctx, groupID)
+}
+
+// GetDashboard records the call and calls GetDashboardFunc.
+func (m *GroupsMock) GetDashboard(ctx context.Context, groupID string, dashboardID string) (*types.Dashboard, error) {
+	m.record("GetDashboard", ctx, groupID, dashboardID)
+	if m.GetDashboardFunc == nil {
+		panic("powerbimock: GroupsMock.GetDashboard called but GetDashboardFunc is not set")
+	}
+	return m.GetDashboardFunc(ctx, groupID, dashboardID)
+}
+
+// GetTile records the call and calls GetTileFunc.
+func (m *GroupsMock) GetTile(ctx context.Context, groupID string, dashboardID string, tileID string) (*types.Tile, error) {
+	m.record("GetTile", ctx, groupID, dashboardID, tileID)
+	if m.GetTileFunc == nil {
+		panic("powerbimock: GroupsMock.GetTile called but GetTileFunc is not set")
+	}
+	return m.GetTileFunc(ctx, groupID, dashboardID, tileID)
+}
+
+// List records the call and calls ListFunc.
+func (m *GroupsMock) List(ctx context.Context, opts types.ListGroupsOptions) ([]types.Group, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		panic("powerbimock: GroupsMock.List called but ListFunc is not set")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// ListDashboards records the call and calls ListDashboardsFunc.
+func (m *GroupsMock) ListDashboards(ctx context.Context, groupID string) ([]types.Dashboard, error) {
+	m.record("ListDashboards", ctx, groupID)
+	if m.ListDashboardsFunc == nil {
+		panic("powerbimock: GroupsMock.ListDashboards called but ListDashboardsFunc is not set")
+	}
+	return m.ListDashboardsFunc(ctx, groupID)
+}
+
+// ListGroupUsers records the call and calls ListGroupUsersFunc.
+func (m *GroupsMock) ListGroupUsers(ctx context.Context, groupID string, opts types.ListGroupUserOptions) ([]types.GroupUser, error) {
+	m.record("ListGroupUsers", ctx, groupID, opts)
+	if m.ListGroupUsersFunc == nil {
+		panic("powerbimock: GroupsMock.ListGroupUsers called but ListGroupUsersFunc is not set")
+	}
+	return m.ListGroupUsersFunc(ctx, groupID, opts)
+}
+
+// ListTiles records the call and calls ListTilesFunc.
+func (m *GroupsMock) ListTiles(ctx context.Context, groupID string, dashboardID string) ([]types.Tile, error) {
+	m.record("ListTiles", ctx, groupID, dashboardID)
+	if m.ListTilesFunc == nil {
+		panic("powerbimock: GroupsMock.ListTiles called but ListTilesFunc is not set")
+	}
+	return m.ListTilesFunc(ctx, groupID, dashboardID)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *GroupsMock) Update(ctx context.Context, groupID string, req types.UpdateGroupRequest) (*types.Group, error) {
+	m.record("Update", ctx, groupID, req)
+	if m.UpdateFunc == nil {
+		panic("powerbimock: GroupsMock.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(ctx, groupID, req)
+}
+
+// UpdateGroupUser records the call and calls UpdateGroupUserFunc.
+func (m *GroupsMock) UpdateGroupUser(ctx context.Context, groupID string, user types.GroupUser) error {
+	m.record("UpdateGroupUser", ctx, groupID, user)
+	if m.UpdateGroupUserFunc == nil {
+		panic("powerbimock: GroupsMock.UpdateGroupUser called but UpdateGroupUserFunc is not set")
+	}
+	return m.UpdateGroupUserFunc(ctx, groupID, user)
+}
+
+var _ powerbi.ProfilesInterface = &ProfilesMock{}
+
+// ProfilesMock is a mock implementation of powerbi.ProfilesInterface.
+type ProfilesMock struct {
+	recorder
+
+	// AllFunc implements All.
+	AllFunc func(context.Context, types.ListProfilesOptions) iter.Seq2[types.ServicePrincipalProfile, error]
+
+	// CreateFunc implements Create.
+	CreateFunc func(context.Context, types.CreateOrUpdateProfileRequest) (*types.ServicePrincipalProfile, error)
+
+	// DeleteFunc implements Delete.
+	DeleteFunc func(context.Context, string) error
+
+	// GetFunc implements Get.
+	GetFunc func(context.Context, string) (*types.ServicePrincipalProfile, error)
+
+	// ListFunc implements List.
+	ListFunc func(context.Context, types.ListProfilesOptions) ([]types.ServicePrincipalProfile, error)
+
+	// UpdateFunc implements Update.
+	UpdateFunc func(context.Context, string, types.CreateOrUpdateProfileRequest) error
+}
+
+// All records the call and calls AllFunc.
+func (m *ProfilesMock) All(ctx context.Context, opts types.ListProfilesOptions) iter.Seq2[types.ServicePrincipalProfile, error] {
+	m.record("All", ctx, opts)
+	if m.AllFunc == nil {
+		panic("powerbimock: ProfilesMock.All called but AllFunc is not set")
+	}
+	return m.AllFunc(ctx, opts)
+}
+
+// Create records the call and calls CreateFunc.
+func (m *ProfilesMock) Create(ctx context.Context, req types.CreateOrUpdateProfileRequest) (*types.ServicePrincipalProfile, error) {
+	m.record("Create", ctx, req)
+	if m.CreateFunc == nil {
+		panic("powerbimock: ProfilesMock.Create called but CreateFunc is not set")
+	}
+	return m.CreateFunc(ctx, req)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *ProfilesMock) Delete(ctx context.Context, profileID string) error {
+	m.record("Delete", ctx, profileID)
+	if m.DeleteFunc == nil {
+		panic("powerbimock: ProfilesMock.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(ctx, profileID)
+}
+
+// Get records the call and calls GetFunc.
+func (m *ProfilesMock) Get(ctx context.Context, profileID string) (*types.ServicePrincipalProfile, error) {
+	m.record("Get", ctx, profileID)
+	if m.GetFunc == nil {
+		panic("powerbimock: ProfilesMock.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(ctx, profileID)
+}
+
+// List records the call and calls ListFunc.
+func (m *ProfilesMock) List(ctx context.Context, opts types.ListProfilesOptions) ([]types.ServicePrincipalProfile, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		panic("powerbimock: ProfilesMock.List called but ListFunc is not set")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *ProfilesMock) Update(ctx context.Context, profileID string, req types.CreateOrUpdateProfileRequest) error {
+	m.record("Update", ctx, profileID, req)
+	if m.UpdateFunc == nil {
+		panic("powerbimock: ProfilesMock.Update called but UpdateFunc is not set")
+	}
+	return m.UpdateFunc(ctx, profileID, req)
+}
+
+var _ powerbi.PushDatasetsInterface = &PushDatasetsMock{}
+
+// PushDatasetsMock is a mock implementation of powerbi.PushDatasetsInterface.
+type PushDatasetsMock struct {
+	recorder
+
+	// DeleteRowsFunc implements DeleteRows.
+	DeleteRowsFunc func(context.Context, string, string) error
+
+	// DeleteRowsInGroupFunc implements DeleteRowsInGroup.
+	DeleteRowsInGroupFunc func(context.Context, string, string, string) error
+
+	// GetTablesFunc implements GetTables.
+	GetTablesFunc func(context.Context, string) ([]types.Table, error)
+
+	// GetTablesInGroupFunc implements GetTablesInGroup.
+	GetTablesInGroupFunc func(context.Context, string, string) ([]types.Table, error)
+
+	// PostDatasetFunc implements PostDataset.
+	PostDatasetFunc func(context.Context, types.CreateDatasetRequest, types.DatasetOptions) (*types.Dataset, error)
+
+	// PostDatasetInGroupFunc implements PostDatasetInGroup.
+	PostDatasetInGroupFunc func(context.Context, string, types.CreateDatasetRequest, types.DatasetOptions) (*types.Dataset, error)
+
+	// PostRowsFunc implements PostRows.
+	PostRowsFunc func(context.Context, string, string, types.PostRowsRequest) error
+
+	// PostRowsInGroupFunc implements PostRowsInGroup.
+	PostRowsInGroupFunc func(context.Context, string, string, string, types.PostRowsRequest) error
+
+	// PutTableFunc implements PutTable.
+	PutTableFunc func(context.Context, string, string, types.Table) (*types.Table, error)
+
+	// PutTableInGroupFunc implements PutTableInGroup.
+	PutTableInGroupFunc func(context.Context, string, string, string, types.Table) (*types.Table, error)
+}
+
+// DeleteRows records the call and calls DeleteRowsFunc.
+func (m *PushDatasetsMock) DeleteRows(ctx context.Context, datasetID string, tableName string) error {
+	m.record("DeleteRows", ctx, datasetID, tableName)
+	if m.DeleteRowsFunc == nil {
+		panic("powerbimock: PushDatasetsMock.DeleteRows called but DeleteRowsFunc is not set")
+	}
+	return m.DeleteRowsFunc(ctx, datasetID, tableName)
+}
+
+// DeleteRowsInGroup records the call and calls DeleteRowsInGroupFunc.
+func (m *PushDatasetsMock) DeleteRowsInGroup(ctx context.Context, groupID string, datasetID string, tableName string) error {
+	m.record("DeleteRowsInGroup", ctx, groupID, datasetID, tableName)
+	if m.DeleteRowsInGroupFunc == nil {
+		panic("powerbimock: PushDatasetsMock.DeleteRowsInGroup called but DeleteRowsInGroupFunc is not set")
+	}
+	return m.DeleteRowsInGroupFunc(ctx, groupID, datasetID, tableName)
+}
+
+// GetTables records the call and calls GetTablesFunc.
+func (m *PushDatasetsMock) GetTables(ctx context.Context, datasetID string) ([]types.Table, error) {
+	m.record("GetTables", ctx, datasetID)
+	if m.GetTablesFunc == nil {
+		panic("powerbimock: PushDatasetsMock.GetTables called but GetTablesFunc is not set")
+	}
+	return m.GetTablesFunc(ctx, datasetID)
+}
+
+// GetTablesInGroup records the call and calls GetTablesInGroupFunc.
+func (m *PushDatasetsMock) GetTablesInGroup(ctx context.Context, groupID string, datasetID string) ([]types.Table, error) {
+	m.record("GetTablesInGroup", ctx, groupID, datasetID)
+	if m.GetTablesInGroupFunc == nil {
+		panic("powerbimock: PushDatasetsMock.GetTablesInGroup called but GetTablesInGroupFunc is not set")
+	}
+	return m.GetTablesInGroupFunc(ctx, groupID, datasetID)
+}
+
+// PostDataset records the call and calls PostDatasetFunc.
+func (m *PushDatasetsMock) PostDataset(ctx context.Context, req types.CreateDatasetRequest, opts types.DatasetOptions) (*types.Dataset, error) {
+	m.record("PostDataset", ctx, req, opts)
+	if m.PostDatasetFunc == nil {
+		panic("powerbimock: PushDatasetsMock.PostDataset called but PostDatasetFunc is not set")
+	}
+	return m.PostDatasetFunc(ctx, req, opts)
+}
+
+// PostDatasetInGroup records the call and calls PostDatasetInGroupFunc.
+func (m *PushDatasetsMock) PostDatasetInGroup(ctx context.Context, groupID string, req types.CreateDatasetRequest, opts types.DatasetOptions) (*types.Dataset, error) {
+	m.record("PostDatasetInGroup", ctx, groupID, req, opts)
+	if m.PostDatasetInGroupFunc == nil {
+		panic("powerbimock: PushDatasetsMock.PostDatasetInGroup called but PostDatasetInGroupFunc is not set")
+	}
+	return m.PostDatasetInGroupFunc(ctx, groupID, req, opts)
+}
+
+// PostRows records the call and calls PostRowsFunc.
+func (m *PushDatasetsMock) PostRows(ctx context.Context, datasetID string, tableName string, req types.PostRowsRequest) error {
+	m.record("PostRows", ctx, datasetID, tableName, req)
+	if m.PostRowsFunc == nil {
+		panic("powerbimock: PushDatasetsMock.PostRows called but PostRowsFunc is not set")
+	}
+	return m.PostRowsFunc(ctx, datasetID, tableName, req)
+}
+
+// PostRowsInGroup records the call and calls PostRowsInGroupFunc.
+func (m *PushDatasetsMock) PostRowsInGroup(ctx context.Context, groupID string, datasetID string, tableName string, req types.PostRowsRequest) error {
+	m.record("PostRowsInGroup", ctx, groupID, datasetID, tableName, req)
+	if m.PostRowsInGroupFunc == nil {
+		panic("powerbimock: PushDatasetsMock.PostRowsInGroup called but PostRowsInGroupFunc is not set")
+	}
+	return m.PostRowsInGroupFunc(ctx, groupID, datasetID, tableName, req)
+}
+
+// PutTable records the call and calls PutTableFunc.
+func (m *PushDatasetsMock) PutTable(ctx context.Context, datasetID string, tableName string, req types.Table) (*types.Table, error) {
+	m.record("PutTable", ctx, datasetID, tableName, req)
+	if m.PutTableFunc == nil {
+		panic("powerbimock: PushDatasetsMock.PutTable called but PutTableFunc is not set")
+	}
+	return m.PutTableFunc(ctx, datasetID, tableName, req)
+}
+
+// PutTableInGroup records the call and calls PutTableInGroupFunc.
+func (m *PushDatasetsMock) PutTableInGroup(ctx context.Context, groupID string, datasetID string, tableName string, req types.Table) (*types.Table, error) {
+	m.record("PutTableInGroup", ctx, groupID, datasetID, tableName, req)
+	if m.PutTableInGroupFunc == nil {
+		panic("powerbimock: PushDatasetsMock.PutTableInGroup called but PutTableInGroupFunc is not set")
+	}
+	return m.PutTableInGroupFunc(ctx, groupID, datasetID, tableName, req)
+}
+
+var _ powerbi.ReportsInterface = &ReportsMock{}
+
+// ReportsMock is a mock implementation of powerbi.ReportsInterface.
+type ReportsMock struct {
+	recorder
+
+	// BindToGatewayFunc implements BindToGateway.
+	BindToGatewayFunc func(context.Context, string, types.RdlBindToGatewayRequest) error
+
+	// BindToGatewayInGroupFunc implements BindToGatewayInGroup.
+	BindToGatewayInGroupFunc func(context.Context, string, string, types.RdlBindToGatewayRequest) error
+
+	// CloneFunc implements Clone.
+	CloneFunc func(context.Context, string, types.CloneReportRequest) (*types.Report, error)
+
+	// CloneInGroupFunc implements CloneInGroup.
+	CloneInGroupFunc func(context.Context, string, string, types.CloneReportRequest) (*types.Report, error)
+
+	// DeleteFunc implements Delete.
+	DeleteFunc func(context.Context, string) error
+
+	// DeleteInGroupFunc implements DeleteInGroup.
+	DeleteInGroupFunc func(context.Context, string, string) error
+
+	// GetFunc implements Get.
+	GetFunc func(context.Context, string) (*types.Report, error)
+
+	// GetInGroupFunc implements GetInGroup.
+	GetInGroupFunc func(context.Context, string, string) (*types.Report, error)
+
+	// GetPageFunc implements GetPage.
+	GetPageFunc func(context.Context, string, string) (*types.Page, error)
+
+	// GetPageInGroupFunc implements GetPageInGroup.
+	GetPageInGroupFunc func(context.Context, string, string, string) (*types.Page, error)
+
+	// ListFunc implements List.
+	ListFunc func(context.Context) ([]types.Report, error)
+
+	// ListInGroupFunc implements ListInGroup.
+	ListInGroupFunc func(context.Context, string) ([]types.Report, error)
+
+	// ListPagesFunc implements ListPages.
+	ListPagesFunc func(context.Context, string) ([]types.Page, error)
+
+	// ListPagesInGroupFunc implements ListPagesInGroup.
+	ListPagesInGroupFunc func(context.Context, string, string) ([]types.Page, error)
+
+	// RebindFunc implements Rebind.
+	RebindFunc func(context.Context, string, types.RebindReportRequest) error
+
+	// RebindInGroupFunc implements RebindInGroup.
+	RebindInGroupFunc func(context.Context, string, string, types.RebindReportRequest) error
+}
+
+// BindToGateway records the call and calls BindToGatewayFunc.
+func (m *ReportsMock) BindToGateway(ctx context.Context, reportID string, req types.RdlBindToGatewayRequest) error {
+	m.record("BindToGateway", ctx, reportID, req)
+	if m.BindToGatewayFunc == nil {
+		panic("powerbimock: ReportsMock.BindToGateway called but BindToGatewayFunc is not set")
+	}
+	return m.BindToGatewayFunc(ctx, reportID, req)
+}
+
+// BindToGatewayInGroup records the call and calls BindToGatewayInGroupFunc.
+func (m *ReportsMock) BindToGatewayInGroup(ctx context.Context, groupID string, reportID string, req types.RdlBindToGatewayRequest) error {
+	m.record("BindToGatewayInGroup", ctx, groupID, reportID, req)
+	if m.BindToGatewayInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.BindToGatewayInGroup called but BindToGatewayInGroupFunc is not set")
+	}
+	return m.BindToGatewayInGroupFunc(ctx, groupID, reportID, req)
+}
+
+// Clone records the call and calls CloneFunc.
+func (m *ReportsMock) Clone(ctx context.Context, reportID string, req types.CloneReportRequest) (*types.Report, error) {
+	m.record("Clone", ctx, reportID, req)
+	if m.CloneFunc == nil {
+		panic("powerbimock: ReportsMock.Clone called but CloneFunc is not set")
+	}
+	return m.CloneFunc(ctx, reportID, req)
+}
+
+// CloneInGroup records the call and calls CloneInGroupFunc.
+func (m *ReportsMock) CloneInGroup(ctx context.Context, groupID string, reportID string, req types.CloneReportRequest) (*types.Report, error) {
+	m.record("CloneInGroup", ctx, groupID, reportID, req)
+	if m.CloneInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.CloneInGroup called but CloneInGroupFunc is not set")
+	}
+	return m.CloneInGroupFunc(ctx, groupID, reportID, req)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *ReportsMock) Delete(ctx context.Context, reportID string) error {
+	m.record("Delete", ctx, reportID)
+	if m.DeleteFunc == nil {
+		panic("powerbimock: ReportsMock.Delete called but DeleteFunc is not set")
+	}
+	return m.DeleteFunc(ctx, reportID)
+}
+
+// DeleteInGroup records the call and calls DeleteInGroupFunc.
+func (m *ReportsMock) DeleteInGroup(ctx context.Context, groupID string, reportID string) error {
+	m.record("DeleteInGroup", ctx, groupID, reportID)
+	if m.DeleteInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.DeleteInGroup called but DeleteInGroupFunc is not set")
+	}
+	return m.DeleteInGroupFunc(ctx, groupID, reportID)
+}
+
+// Get records the call and calls GetFunc.
+func (m *ReportsMock) Get(ctx context.Context, reportID string) (*types.Report, error) {
+	m.record("Get", ctx, reportID)
+	if m.GetFunc == nil {
+		panic("powerbimock: ReportsMock.Get called but GetFunc is not set")
+	}
+	return m.GetFunc(ctx, reportID)
+}
+
+// GetInGroup records the call and calls GetInGroupFunc.
+func (m *ReportsMock) GetInGroup(ctx context.Context, groupID string, reportID string) (*types.Report, error) {
+	m.record("GetInGroup", ctx, groupID, reportID)
+	if m.GetInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.GetInGroup called but GetInGroupFunc is not set")
+	}
+	return m.GetInGroupFunc(ctx, groupID, reportID)
+}
+
+// GetPage records the call and calls GetPageFunc.
+func (m *ReportsMock) GetPage(ctx context.Context, reportID string, pageName string) (*types.Page, error) {
+	m.record("GetPage", ctx, reportID, pageName)
+	if m.GetPageFunc == nil {
+		panic("powerbimock: ReportsMock.GetPage called but GetPageFunc is not set")
+	}
+	return m.GetPageFunc(ctx, reportID, pageName)
+}
+
+// GetPageInGroup records the call and calls GetPageInGroupFunc.
+func (m *ReportsMock) GetPageInGroup(ctx context.Context, groupID string, reportID string, pageName string) (*types.Page, error) {
+	m.record("GetPageInGroup", ctx, groupID, reportID, pageName)
+	if m.GetPageInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.GetPageInGroup called but GetPageInGroupFunc is not set")
+	}
+	return m.GetPageInGroupFunc(ctx, groupID, reportID, pageName)
+}
+
+// List records the call and calls ListFunc.
+func (m *ReportsMock) List(ctx context.Context) ([]types.Report, error) {
+	m.record("List", ctx)
+	if m.ListFunc == nil {
+		panic("powerbimock: ReportsMock.List called but ListFunc is not set")
+	}
+	return m.ListFunc(ctx)
+}
+
+// ListInGroup records the call and calls ListInGroupFunc.
+func (m *ReportsMock) ListInGroup(ctx context.Context, groupID string) ([]types.Report, error) {
+	m.record("ListInGroup", ctx, groupID)
+	if m.ListInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.ListInGroup called but ListInGroupFunc is not set")
+	}
+	return m.ListInGroupFunc(ctx, groupID)
+}
+
+// ListPages records the call and calls ListPagesFunc.
+func (m *ReportsMock) ListPages(ctx context.Context, reportID string) ([]types.Page, error) {
+	m.record("ListPages", ctx, reportID)
+	if m.ListPagesFunc == nil {
+		panic("powerbimock: ReportsMock.ListPages called but ListPagesFunc is not set")
+	}
+	return m.ListPagesFunc(ctx, reportID)
+}
+
+// ListPagesInGroup records the call and calls ListPagesInGroupFunc.
+func (m *ReportsMock) ListPagesInGroup(ctx context.Context, groupID string, reportID string) ([]types.Page, error) {
+	m.record("ListPagesInGroup", ctx, groupID, reportID)
+	if m.ListPagesInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.ListPagesInGroup called but ListPagesInGroupFunc is not set")
+	}
+	return m.ListPagesInGroupFunc(ctx, groupID, reportID)
+}
+
+// Rebind records the call and calls RebindFunc.
+func (m *ReportsMock) Rebind(ctx context.Context, reportID string, req types.RebindReportRequest) error {
+	m.record("Rebind", ctx, reportID, req)
+	if m.RebindFunc == nil {
+		panic("powerbimock: ReportsMock.Rebind called but RebindFunc is not set")
+	}
+	return m.RebindFunc(ctx, reportID, req)
+}
+
+// RebindInGroup records the call and calls RebindInGroupFunc.
+func (m *ReportsMock) RebindInGroup(ctx context.Context, groupID string, reportID string, req types.RebindReportRequest) error {
+	m.record("RebindInGroup", ctx, groupID, reportID, req)
+	if m.RebindInGroupFunc == nil {
+		panic("powerbimock: ReportsMock.RebindInGroup called but RebindInGroupFunc is not set")
+	}
+	return m.RebindInGroupFunc(ctx, groupID, reportID, req)
+}
@@ -0,0 +1,154 @@
+package powerbitest
+
+import (
+	"net/http"
+
+	"github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func (s *Server) registerDashboards(mux *http.ServeMux) {
+	s.handleBoth(mux, http.MethodGet, "/dashboards", s.listDashboards)
+	s.handleBoth(mux, http.MethodPost, "/dashboards", s.addDashboard)
+	s.handleBoth(mux, http.MethodGet, "/dashboards/{dashboardId}", s.getDashboard)
+	s.handleBoth(mux, http.MethodDelete, "/dashboards/{dashboardId}", s.deleteDashboard)
+	s.handleBoth(mux, http.MethodGet, "/dashboards/{dashboardId}/tiles", s.listTiles)
+	s.handleBoth(mux, http.MethodGet, "/dashboards/{dashboardId}/tiles/{tileId}", s.getTile)
+	s.handleBoth(mux, http.MethodPost, "/dashboards/{dashboardId}/tiles/{tileId}/Clone", s.cloneTile)
+}
+
+// AddDashboard adds a dashboard with the given tiles to the specified
+// workspace, or to My workspace if groupID is empty, and returns it. IDs are
+// generated for the dashboard and tiles that have none.
+func (s *Server) AddDashboard(groupID string, d types.Dashboard, tiles ...types.Tile) types.Dashboard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ws := s.mustWorkspace(groupID)
+	if d.ID == "" {
+		d.ID = newID()
+	}
+	if d.EmbedURL == "" {
+		d.EmbedURL = powerbi.CloudPublic.DashboardEmbedURL(groupID, d.ID)
+	}
+	tiles = append([]types.Tile(nil), tiles...)
+	for i := range tiles {
+		if tiles[i].ID == "" {
+			tiles[i].ID = newID()
+		}
+	}
+	ws.dashboards = append(ws.dashboards, &dashboard{Dashboard: d, tiles: tiles})
+	return d
+}
+
+// dashboardFor returns the dashboard addressed by r, writing a 404 error if it
+// does not exist. s.mu must be held.
+func (s *Server) dashboardFor(w http.ResponseWriter, r *http.Request) (*workspace, *dashboard) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return nil, nil
+	}
+	d, _ := find(ws.dashboards, func(d *dashboard) bool { return d.ID == r.PathValue("dashboardId") })
+	if d == nil {
+		writeNotFound(w)
+	}
+	return ws, d
+}
+
+func (s *Server) listDashboards(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	dashboards := []types.Dashboard{}
+	for _, d := range ws.dashboards {
+		dashboards = append(dashboards, d.Dashboard)
+	}
+	writeJSON(w, http.StatusOK, types.DashboardList{Value: dashboards})
+}
+
+func (s *Server) addDashboard(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	var req types.AddDashboardRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	d := &dashboard{Dashboard: types.Dashboard{ID: newID(), DisplayName: req.Name}}
+	d.EmbedURL = powerbi.CloudPublic.DashboardEmbedURL(ws.group.ID, d.ID)
+	ws.dashboards = append(ws.dashboards, d)
+	writeJSON(w, http.StatusOK, d.Dashboard)
+}
+
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request) {
+	if _, d := s.dashboardFor(w, r); d != nil {
+		writeJSON(w, http.StatusOK, d.Dashboard)
+	}
+}
+
+func (s *Server) deleteDashboard(w http.ResponseWriter, r *http.Request) {
+	ws, d := s.dashboardFor(w, r)
+	if d == nil {
+		return
+	}
+	_, i := find(ws.dashboards, func(other *dashboard) bool { return other == d })
+	ws.dashboards = remove(ws.dashboards, i)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listTiles(w http.ResponseWriter, r *http.Request) {
+	if _, d := s.dashboardFor(w, r); d != nil {
+		writeJSON(w, http.StatusOK, types.TileList{Value: append([]types.Tile{}, d.tiles...)})
+	}
+}
+
+func (s *Server) getTile(w http.ResponseWriter, r *http.Request) {
+	_, d := s.dashboardFor(w, r)
+	if d == nil {
+		return
+	}
+	t, i := find(d.tiles, func(t types.Tile) bool { return t.ID == r.PathValue("tileId") })
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) cloneTile(w http.ResponseWriter, r *http.Request) {
+	ws, d := s.dashboardFor(w, r)
+	if d == nil {
+		return
+	}
+	t, i := find(d.tiles, func(t types.Tile) bool { return t.ID == r.PathValue("tileId") })
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	var req types.CloneTileRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.TargetWorkspaceID != "" {
+		if ws = s.workspace(req.TargetWorkspaceID); ws == nil {
+			writeNotFound(w)
+			return
+		}
+	}
+	target, _ := find(ws.dashboards, func(d *dashboard) bool { return d.ID == req.TargetDashboardID })
+	if target == nil {
+		writeNotFound(w)
+		return
+	}
+	t.ID = newID()
+	if req.TargetReportID != "" {
+		t.ReportID = req.TargetReportID
+	}
+	if req.TargetModelID != "" {
+		t.DatasetID = req.TargetModelID
+	}
+	target.tiles = append(target.tiles, t)
+	writeJSON(w, http.StatusOK, t)
+}
@@ -0,0 +1,74 @@
+package powerbitest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/powerbitest"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestDashboards(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+
+	mine, err := pbi.Dashboards.Add(ctx, types.AddDashboardRequest{Name: "mine"})
+	if err != nil || mine.DisplayName != "mine" {
+		t.Fatalf("Add() = %v, %v", mine, err)
+	}
+	d := srv.AddDashboard(g.ID, types.Dashboard{DisplayName: "kpis"}, types.Tile{Title: "revenue"}, types.Tile{Title: "margin"})
+	target, err := pbi.Groups.AddDashboard(ctx, g.ID, types.AddDashboardRequest{Name: "target"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dashboards, err := pbi.Dashboards.List(ctx)
+	if err := checkLen(dashboards, err, 1); err != nil {
+		t.Errorf("List(): %v", err)
+	}
+	if got, err := pbi.Dashboards.Get(ctx, mine.ID); err != nil || got.ID != mine.ID {
+		t.Errorf("Get() = %v, %v", got, err)
+	}
+	dashboards, err = pbi.Groups.ListDashboards(ctx, g.ID)
+	if err := checkLen(dashboards, err, 2); err != nil {
+		t.Errorf("ListDashboards(): %v", err)
+	}
+	if got, err := pbi.Groups.GetDashboard(ctx, g.ID, d.ID); err != nil || got.DisplayName != "kpis" || got.EmbedURL == "" {
+		t.Errorf("GetDashboard() = %v, %v", got, err)
+	}
+
+	tiles, err := pbi.Groups.ListTiles(ctx, g.ID, d.ID)
+	if err := checkLen(tiles, err, 2); err != nil {
+		t.Fatalf("ListTiles(): %v", err)
+	}
+	if got, err := pbi.Groups.GetTile(ctx, g.ID, d.ID, tiles[1].ID); err != nil || got.Title != "margin" {
+		t.Errorf("GetTile() = %v, %v", got, err)
+	}
+	if _, err := pbi.Groups.GetTile(ctx, g.ID, d.ID, "missing"); !types.IsNotFound(err) {
+		t.Errorf("GetTile() of a missing tile: error %v, want 404", err)
+	}
+
+	clone, err := pbi.Groups.CloneTile(ctx, g.ID, d.ID, tiles[0].ID, types.CloneTileRequest{TargetDashboardID: target.ID, TargetReportID: "r2"})
+	if err != nil || clone.ID == tiles[0].ID || clone.ReportID != "r2" {
+		t.Errorf("CloneTile() = %v, %v", clone, err)
+	}
+	tiles, err = pbi.Groups.ListTiles(ctx, g.ID, target.ID)
+	if err := checkLen(tiles, err, 1); err != nil {
+		t.Errorf("ListTiles() of the target dashboard: %v", err)
+	}
+	if _, err := pbi.Groups.CloneTile(ctx, g.ID, d.ID, tiles[0].ID, types.CloneTileRequest{TargetDashboardID: "missing"}); !types.IsNotFound(err) {
+		t.Errorf("CloneTile() to a missing dashboard: error %v, want 404", err)
+	}
+
+	if err := pbi.Dashboards.Delete(ctx, mine.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pbi.Dashboards.Get(ctx, mine.ID); !types.IsNotFound(err) {
+		t.Errorf("Get() of a deleted dashboard: error %v, want 404", err)
+	}
+	if err := pbi.Groups.DeleteDashboard(ctx, g.ID, d.ID); err != nil {
+		t.Fatal(err)
+	}
+}
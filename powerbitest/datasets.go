@@ -0,0 +1,215 @@
+package powerbitest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func (s *Server) registerDatasets(mux *http.ServeMux) {
+	s.handleBoth(mux, http.MethodGet, "/datasets", s.listDatasets)
+	s.handleBoth(mux, http.MethodPost, "/datasets", s.postDataset)
+	s.handleBoth(mux, http.MethodGet, "/datasets/{datasetId}", s.getDataset)
+	s.handleBoth(mux, http.MethodDelete, "/datasets/{datasetId}", s.deleteDataset)
+	s.handleBoth(mux, http.MethodGet, "/datasets/{datasetId}/tables", s.listTables)
+	s.handleBoth(mux, http.MethodPut, "/datasets/{datasetId}/tables/{tableName}", s.putTable)
+	s.handleBoth(mux, http.MethodPost, "/datasets/{datasetId}/tables/{tableName}/rows", s.postRows)
+	s.handleBoth(mux, http.MethodDelete, "/datasets/{datasetId}/tables/{tableName}/rows", s.deleteRows)
+	s.handleBoth(mux, http.MethodPost, "/datasets/{datasetId}/executeQueries", s.executeQueries)
+}
+
+// AddDataset adds a dataset with the given tables to the specified workspace,
+// or to My workspace if groupID is empty, and returns it. An ID is generated
+// if d has none.
+func (s *Server) AddDataset(groupID string, d types.Dataset, tables ...types.Table) types.Dataset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ws := s.mustWorkspace(groupID)
+	if d.ID == "" {
+		d.ID = newID()
+	}
+	ds := &dataset{Dataset: d}
+	for _, t := range tables {
+		ds.tables = append(ds.tables, &table{Table: t})
+	}
+	ws.datasets = append(ws.datasets, ds)
+	return d
+}
+
+// Rows returns the rows pushed to the specified table of a dataset in the
+// specified workspace, or in My workspace if groupID is empty.
+func (s *Server) Rows(groupID, datasetID, tableName string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ds, _ := find(s.mustWorkspace(groupID).datasets, func(d *dataset) bool { return d.ID == datasetID })
+	if ds == nil {
+		panic(fmt.Sprintf("powerbitest: dataset %q does not exist", datasetID))
+	}
+	t, _ := find(ds.tables, func(t *table) bool { return t.Name == tableName })
+	if t == nil {
+		panic(fmt.Sprintf("powerbitest: table %q does not exist", tableName))
+	}
+	rows := make([]map[string]any, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = maps.Clone(row)
+	}
+	return rows
+}
+
+// ScriptQuery makes ExecuteQueries answer query against the specified dataset
+// with result. An empty query scripts the result of every query against the
+// dataset that has no result of its own.
+func (s *Server) ScriptQuery(datasetID, query string, result types.DatasetExecuteQueriesQueryResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries[queryKey{datasetID: datasetID, query: query}] = result
+}
+
+// datasetFor returns the dataset addressed by r, writing a 404 error if it
+// does not exist. s.mu must be held.
+func (s *Server) datasetFor(w http.ResponseWriter, r *http.Request) (*workspace, *dataset) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return nil, nil
+	}
+	ds, _ := find(ws.datasets, func(d *dataset) bool { return d.ID == r.PathValue("datasetId") })
+	if ds == nil {
+		writeNotFound(w)
+	}
+	return ws, ds
+}
+
+// tableFor returns the table addressed by r, writing a 404 error if it does
+// not exist. s.mu must be held.
+func (s *Server) tableFor(w http.ResponseWriter, r *http.Request) *table {
+	_, ds := s.datasetFor(w, r)
+	if ds == nil {
+		return nil
+	}
+	t, _ := find(ds.tables, func(t *table) bool { return t.Name == r.PathValue("tableName") })
+	if t == nil {
+		writeNotFound(w)
+	}
+	return t
+}
+
+func (s *Server) listDatasets(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	datasets := []types.Dataset{}
+	for _, ds := range ws.datasets {
+		datasets = append(datasets, ds.Dataset)
+	}
+	writeJSON(w, http.StatusOK, types.DatasetList{Value: datasets})
+}
+
+func (s *Server) postDataset(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	var req types.CreateDatasetRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	ds := &dataset{Dataset: types.Dataset{ID: newID(), Name: req.Name, AddRowsAPIEnabled: true}}
+	for _, t := range req.Tables {
+		ds.tables = append(ds.tables, &table{Table: t})
+	}
+	ws.datasets = append(ws.datasets, ds)
+	writeJSON(w, http.StatusCreated, ds.Dataset)
+}
+
+func (s *Server) getDataset(w http.ResponseWriter, r *http.Request) {
+	if _, ds := s.datasetFor(w, r); ds != nil {
+		writeJSON(w, http.StatusOK, ds.Dataset)
+	}
+}
+
+func (s *Server) deleteDataset(w http.ResponseWriter, r *http.Request) {
+	ws, ds := s.datasetFor(w, r)
+	if ds == nil {
+		return
+	}
+	_, i := find(ws.datasets, func(other *dataset) bool { return other == ds })
+	ws.datasets = remove(ws.datasets, i)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listTables(w http.ResponseWriter, r *http.Request) {
+	_, ds := s.datasetFor(w, r)
+	if ds == nil {
+		return
+	}
+	tables := []types.Table{}
+	for _, t := range ds.tables {
+		tables = append(tables, t.Table)
+	}
+	writeJSON(w, http.StatusOK, types.TableList{Value: tables})
+}
+
+func (s *Server) putTable(w http.ResponseWriter, r *http.Request) {
+	t := s.tableFor(w, r)
+	if t == nil {
+		return
+	}
+	var req types.Table
+	if !decode(w, r, &req) {
+		return
+	}
+	req.Name = t.Name
+	t.Table = req
+	writeJSON(w, http.StatusOK, t.Table)
+}
+
+func (s *Server) postRows(w http.ResponseWriter, r *http.Request) {
+	t := s.tableFor(w, r)
+	if t == nil {
+		return
+	}
+	var req types.PostRowsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	t.rows = append(t.rows, req.Rows...)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteRows(w http.ResponseWriter, r *http.Request) {
+	if t := s.tableFor(w, r); t != nil {
+		t.rows = nil
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) executeQueries(w http.ResponseWriter, r *http.Request) {
+	_, ds := s.datasetFor(w, r)
+	if ds == nil {
+		return
+	}
+	var req types.DatasetExecuteQueriesRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Queries) != 1 {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "exactly one query must be provided")
+		return
+	}
+
+	query := req.Queries[0].Query
+	result, ok := s.queries[queryKey{datasetID: ds.ID, query: query}]
+	if !ok {
+		result, ok = s.queries[queryKey{datasetID: ds.ID}]
+	}
+	if !ok {
+		writeError(w, http.StatusBadRequest, "DatasetExecuteQueriesError", fmt.Sprintf("no result scripted for query %q", query))
+		return
+	}
+	writeJSON(w, http.StatusOK, types.DatasetExecuteQueriesResponse{
+		Results: []types.DatasetExecuteQueriesQueryResult{result},
+	})
+}
@@ -0,0 +1,122 @@
+package powerbitest_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/powerbitest"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestPushDatasets(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+
+	ds, err := pbi.PushDatasets.PostDatasetInGroup(ctx, g.ID, types.CreateDatasetRequest{
+		Name:   "orders",
+		Tables: []types.Table{{Name: "Orders", Columns: []types.Column{{Name: "ID", DataType: "Int64"}}}},
+	}, types.DatasetOptions{})
+	if err != nil || ds.ID == "" || !ds.AddRowsAPIEnabled {
+		t.Fatalf("PostDatasetInGroup() = %v, %v", ds, err)
+	}
+
+	tables, err := pbi.PushDatasets.GetTablesInGroup(ctx, g.ID, ds.ID)
+	if err := checkLen(tables, err, 1); err != nil {
+		t.Errorf("GetTablesInGroup(): %v", err)
+	}
+	table, err := pbi.PushDatasets.PutTableInGroup(ctx, g.ID, ds.ID, "Orders", types.Table{
+		Columns: []types.Column{{Name: "ID", DataType: "Int64"}, {Name: "Amount", DataType: "Double"}},
+	})
+	if err != nil || table.Name != "Orders" || len(table.Columns) != 2 {
+		t.Errorf("PutTableInGroup() = %v, %v", table, err)
+	}
+
+	rows := []map[string]any{{"ID": 1.0, "Amount": 2.5}, {"ID": 2.0, "Amount": 4.0}}
+	if err := pbi.PushDatasets.PostRowsInGroup(ctx, g.ID, ds.ID, "Orders", types.PostRowsRequest{Rows: rows}); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Rows(g.ID, ds.ID, "Orders"); !reflect.DeepEqual(got, rows) {
+		t.Errorf("Rows() = %v, want %v", got, rows)
+	}
+	if err := pbi.PushDatasets.PostRowsInGroup(ctx, g.ID, ds.ID, "Missing", types.PostRowsRequest{Rows: rows}); !types.IsNotFound(err) {
+		t.Errorf("PostRowsInGroup() to a missing table: error %v, want 404", err)
+	}
+	if err := pbi.PushDatasets.DeleteRowsInGroup(ctx, g.ID, ds.ID, "Orders"); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Rows(g.ID, ds.ID, "Orders"); len(got) != 0 {
+		t.Errorf("Rows() after DeleteRowsInGroup() = %v", got)
+	}
+
+	// My workspace routes share the handlers.
+	mine, err := pbi.PushDatasets.PostDataset(ctx, types.CreateDatasetRequest{Name: "mine", Tables: []types.Table{{Name: "T"}}}, types.DatasetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pbi.PushDatasets.PostRows(ctx, mine.ID, "T", types.PostRowsRequest{Rows: rows[:1]}); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Rows("", mine.ID, "T"); len(got) != 1 {
+		t.Errorf("Rows() of My workspace = %v", got)
+	}
+}
+
+func TestDatasets(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+	ds := srv.AddDataset(g.ID, types.Dataset{Name: "model"})
+	srv.AddDataset("", types.Dataset{Name: "mine"})
+
+	list, err := pbi.Datasets.Group().Datasets(ctx, g.ID)
+	if err != nil || len(list.Value) != 1 || list.Value[0].ID != ds.ID {
+		t.Errorf("Group().Datasets() = %v, %v", list, err)
+	}
+	list, err = pbi.Datasets.Datasets(ctx)
+	if err != nil || len(list.Value) != 1 || list.Value[0].Name != "mine" {
+		t.Errorf("Datasets() = %v, %v", list, err)
+	}
+	if got, err := pbi.Datasets.Group().Dataset(ctx, g.ID, ds.ID); err != nil || got.Name != "model" {
+		t.Errorf("Group().Dataset() = %v, %v", got, err)
+	}
+	if err := pbi.Datasets.Group().DeleteDataset(ctx, g.ID, ds.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pbi.Datasets.Group().Dataset(ctx, g.ID, ds.ID); !types.IsNotFound(err) {
+		t.Errorf("Group().Dataset() of a deleted dataset: error %v, want 404", err)
+	}
+}
+
+func TestExecuteQueries(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+	ds := srv.AddDataset(g.ID, types.Dataset{Name: "model"})
+
+	srv.ScriptQuery(ds.ID, "EVALUATE 'Sales'", types.DatasetExecuteQueriesQueryResult{
+		Tables: []types.DatasetExecuteQueriesTableResult{{Rows: []any{map[string]any{"Sales[ID]": 1.0}}}},
+	})
+	query := func(q string) (*types.DatasetExecuteQueriesResponse, error) {
+		return pbi.Datasets.Group().ExecuteQueries(ctx, g.ID, ds.ID, types.DatasetExecuteQueriesRequest{
+			Queries: []types.DatasetExecuteQueriesQuery{{Query: q}},
+		})
+	}
+
+	resp, err := query("EVALUATE 'Sales'")
+	if err != nil || len(resp.Results) != 1 || len(resp.Results[0].Tables[0].Rows) != 1 {
+		t.Fatalf("ExecuteQueries() = %v, %v", resp, err)
+	}
+	if _, err := query("EVALUATE 'Other'"); !types.HasErrorCode(err, "DatasetExecuteQueriesError") {
+		t.Errorf("ExecuteQueries() of an unscripted query: error %v, want DatasetExecuteQueriesError", err)
+	}
+
+	srv.ScriptQuery(ds.ID, "", types.DatasetExecuteQueriesQueryResult{})
+	if _, err := query("EVALUATE 'Other'"); err != nil {
+		t.Errorf("ExecuteQueries() with a default result: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package powerbitest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// embedTokenLifetime is the lifetime of tokens issued without an explicit
+// lifetimeInMinutes.
+const embedTokenLifetime = time.Hour
+
+func (s *Server) registerEmbedToken(mux *http.ServeMux) {
+	s.handle(mux, "POST /GenerateToken", s.generateToken)
+	s.handle(mux, "POST /groups/{groupId}/reports/GenerateToken", s.generateTokenInGroup(nil))
+	s.handle(mux, "POST /groups/{groupId}/reports/{reportId}/GenerateToken", s.generateTokenInGroup(func(ws *workspace, r *http.Request) bool {
+		rep, _ := find(ws.reports, func(rep *report) bool { return rep.ID == r.PathValue("reportId") })
+		return rep != nil
+	}))
+	s.handle(mux, "POST /groups/{groupId}/dashboards/{dashboardId}/GenerateToken", s.generateTokenInGroup(func(ws *workspace, r *http.Request) bool {
+		d, _ := find(ws.dashboards, func(d *dashboard) bool { return d.ID == r.PathValue("dashboardId") })
+		return d != nil
+	}))
+	s.handle(mux, "POST /groups/{groupId}/dashboards/{dashboardId}/tiles/{tileId}/GenerateToken", s.generateTokenInGroup(func(ws *workspace, r *http.Request) bool {
+		d, _ := find(ws.dashboards, func(d *dashboard) bool { return d.ID == r.PathValue("dashboardId") })
+		if d == nil {
+			return false
+		}
+		_, i := find(d.tiles, func(t types.Tile) bool { return t.ID == r.PathValue("tileId") })
+		return i >= 0
+	}))
+	s.handle(mux, "POST /groups/{groupId}/datasets/{datasetId}/GenerateToken", s.generateTokenInGroup(func(ws *workspace, r *http.Request) bool {
+		ds, _ := find(ws.datasets, func(d *dataset) bool { return d.ID == r.PathValue("datasetId") })
+		return ds != nil
+	}))
+}
+
+func (s *Server) generateToken(w http.ResponseWriter, r *http.Request) {
+	var req types.GenerateTokenRequestV2
+	if !decode(w, r, &req) {
+		return
+	}
+	for _, rep := range req.Reports {
+		if !s.exists(func(ws *workspace) bool {
+			found, _ := find(ws.reports, func(other *report) bool { return other.ID == rep.ID })
+			return found != nil
+		}) {
+			writeNotFound(w)
+			return
+		}
+	}
+	for _, ds := range req.Datasets {
+		if !s.exists(func(ws *workspace) bool {
+			found, _ := find(ws.datasets, func(other *dataset) bool { return other.ID == ds.ID })
+			return found != nil
+		}) {
+			writeNotFound(w)
+			return
+		}
+	}
+	for _, tw := range req.TargetWorkspaces {
+		if s.workspace(tw.ID) == nil {
+			writeNotFound(w)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, newEmbedToken(req.LifetimeInMinutes))
+}
+
+// generateTokenInGroup returns a handler that issues an embed token if exists
+// reports that the artifact addressed by the request exists in its workspace.
+// A nil exists only requires the workspace to exist.
+func (s *Server) generateTokenInGroup(exists func(ws *workspace, r *http.Request) bool) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ws := s.workspaceFor(w, r)
+		if ws == nil {
+			return
+		}
+		if exists != nil && !exists(ws, r) {
+			writeNotFound(w)
+			return
+		}
+		var req types.GenerateTokenRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		writeJSON(w, http.StatusOK, newEmbedToken(req.LifetimeInMinutes))
+	}
+}
+
+// exists reports whether match returns true for any workspace. s.mu must be
+// held.
+func (s *Server) exists(match func(ws *workspace) bool) bool {
+	_, i := find(s.workspaces, match)
+	return i >= 0
+}
+
+// newEmbedToken returns an opaque embed token valid for the given number of
+// minutes, or for an hour if minutes is zero.
+func newEmbedToken(minutes int) types.EmbedToken {
+	lifetime := embedTokenLifetime
+	if minutes > 0 {
+		lifetime = time.Duration(minutes) * time.Minute
+	}
+	var b [48]byte
+	_, _ = rand.Read(b[:])
+	return types.EmbedToken{
+		Expiration: time.Now().Add(lifetime).UTC().Format(time.RFC3339),
+		Token:      "H4sI" + base64.RawURLEncoding.EncodeToString(b[:]),
+		TokenID:    newID(),
+	}
+}
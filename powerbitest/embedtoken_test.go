@@ -0,0 +1,70 @@
+package powerbitest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/powerbitest"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestGenerateToken(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+	r := srv.AddReport(g.ID, types.Report{Name: "revenue"})
+	ds := srv.AddDataset(g.ID, types.Dataset{Name: "model"})
+
+	token, err := pbi.EmbedToken.GenerateToken(ctx, types.GenerateTokenRequestV2{
+		Reports:           []types.GenerateTokenRequestV2Report{{ID: r.ID}},
+		Datasets:          []types.GenerateTokenRequestV2Dataset{{ID: ds.ID}},
+		TargetWorkspaces:  []types.GenerateTokenRequestV2TargetWorkspace{{ID: g.ID}},
+		LifetimeInMinutes: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token == "" || token.TokenID == "" {
+		t.Errorf("GenerateToken() = %+v", token)
+	}
+	expiration, err := time.Parse(time.RFC3339, token.Expiration)
+	if err != nil || time.Until(expiration) > 10*time.Minute || time.Until(expiration) < 9*time.Minute {
+		t.Errorf("GenerateToken() expiration = %q, want in 10 minutes", token.Expiration)
+	}
+
+	if _, err := pbi.EmbedToken.GenerateToken(ctx, types.GenerateTokenRequestV2{
+		Reports: []types.GenerateTokenRequestV2Report{{ID: "missing"}},
+	}); !types.IsNotFound(err) {
+		t.Errorf("GenerateToken() for a missing report: error %v, want 404", err)
+	}
+
+	if _, err := pbi.EmbedToken.GenerateTokenForReportsInGroup(ctx, g.ID, r.ID, types.GenerateTokenRequest{}); err != nil {
+		t.Errorf("GenerateTokenForReportsInGroup(): %v", err)
+	}
+	if _, err := pbi.EmbedToken.GenerateTokenForReportsInGroup(ctx, g.ID, "missing", types.GenerateTokenRequest{}); !types.IsNotFound(err) {
+		t.Errorf("GenerateTokenForReportsInGroup() for a missing report: error %v, want 404", err)
+	}
+	if _, err := pbi.EmbedToken.GenerateTokenForReportsCreateInGroup(ctx, g.ID, types.GenerateTokenRequest{DatasetID: ds.ID}); err != nil {
+		t.Errorf("GenerateTokenForReportsCreateInGroup(): %v", err)
+	}
+	if _, err := pbi.EmbedToken.GenerateTokenForDatasetsInGroup(ctx, g.ID, ds.ID, types.GenerateTokenRequest{}); err != nil {
+		t.Errorf("GenerateTokenForDatasetsInGroup(): %v", err)
+	}
+
+	d := srv.AddDashboard(g.ID, types.Dashboard{DisplayName: "kpis"}, types.Tile{Title: "revenue"})
+	tiles, err := pbi.Groups.ListTiles(ctx, g.ID, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pbi.EmbedToken.GenerateTokenForDashboardsInGroup(ctx, g.ID, d.ID, types.GenerateTokenRequest{}); err != nil {
+		t.Errorf("GenerateTokenForDashboardsInGroup(): %v", err)
+	}
+	if _, err := pbi.EmbedToken.GenerateTokenForTilesInGroup(ctx, g.ID, d.ID, tiles[0].ID, types.GenerateTokenRequest{}); err != nil {
+		t.Errorf("GenerateTokenForTilesInGroup(): %v", err)
+	}
+	if _, err := pbi.EmbedToken.GenerateTokenForTilesInGroup(ctx, g.ID, d.ID, "missing", types.GenerateTokenRequest{}); !types.IsNotFound(err) {
+		t.Errorf("GenerateTokenForTilesInGroup() for a missing tile: error %v, want 404", err)
+	}
+}
@@ -0,0 +1,160 @@
+package powerbitest
+
+import (
+	"net/http"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func (s *Server) registerGroups(mux *http.ServeMux) {
+	s.handle(mux, "POST /groups", s.createGroup)
+	s.handle(mux, "GET /groups", s.listGroups)
+	s.handle(mux, "GET /groups/{groupId}", s.getGroup)
+	s.handle(mux, "PATCH /groups/{groupId}", s.updateGroup)
+	s.handle(mux, "DELETE /groups/{groupId}", s.deleteGroup)
+	s.handle(mux, "GET /groups/{groupId}/users", s.listGroupUsers)
+	s.handle(mux, "POST /groups/{groupId}/users", s.addGroupUser)
+	s.handle(mux, "PUT /groups/{groupId}/users", s.updateGroupUser)
+	s.handle(mux, "DELETE /groups/{groupId}/users/{user}", s.deleteGroupUser)
+}
+
+// AddGroup adds a workspace named name and returns it.
+func (s *Server) AddGroup(name string) types.Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addGroup(name)
+}
+
+// GroupUsers returns the users of the specified workspace.
+func (s *Server) GroupUsers(groupID string) []types.GroupUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.GroupUser(nil), s.mustWorkspace(groupID).users...)
+}
+
+func (s *Server) addGroup(name string) types.Group {
+	ws := &workspace{group: types.Group{ID: newID(), Name: name}}
+	s.workspaces = append(s.workspaces, ws)
+	return ws.group
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	var req types.CreateGroupRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	for _, ws := range s.workspaces {
+		if ws.group.ID != "" && ws.group.Name == req.Name {
+			writeError(w, http.StatusConflict, "PowerBIEntityAlreadyExists", "workspace name already exists")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, s.addGroup(req.Name))
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	groups := []types.Group{}
+	for _, ws := range s.workspaces {
+		if ws.group.ID != "" {
+			groups = append(groups, ws.group)
+		}
+	}
+	writeJSON(w, http.StatusOK, types.GroupList{Value: page(r, groups)})
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	if ws := s.workspaceFor(w, r); ws != nil {
+		writeJSON(w, http.StatusOK, ws.group)
+	}
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	var req types.UpdateGroupRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name != "" {
+		ws.group.Name = req.Name
+	}
+	if req.DefaultDatasetStorageFormat != "" {
+		ws.group.DefaultDatasetStorageFormat = req.DefaultDatasetStorageFormat
+	}
+	writeJSON(w, http.StatusOK, ws.group)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	if s.workspaceFor(w, r) == nil {
+		return
+	}
+	_, i := find(s.workspaces, func(ws *workspace) bool { return ws.group.ID == r.PathValue("groupId") })
+	s.workspaces = remove(s.workspaces, i)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listGroupUsers(w http.ResponseWriter, r *http.Request) {
+	if ws := s.workspaceFor(w, r); ws != nil {
+		writeJSON(w, http.StatusOK, types.GroupUserList{Value: page(r, append([]types.GroupUser{}, ws.users...))})
+	}
+}
+
+func (s *Server) addGroupUser(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	var user types.GroupUser
+	if !decode(w, r, &user) {
+		return
+	}
+	if _, i := find(ws.users, sameUser(user.Identifier)); i >= 0 {
+		writeError(w, http.StatusBadRequest, "AddingAlreadyExistsGroupUserNotSupportedError", "user already has access")
+		return
+	}
+	ws.users = append(ws.users, user)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateGroupUser(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	var user types.GroupUser
+	if !decode(w, r, &user) {
+		return
+	}
+	_, i := find(ws.users, sameUser(user.Identifier))
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	ws.users[i] = user
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteGroupUser(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	user := r.PathValue("user")
+	_, i := find(ws.users, func(u types.GroupUser) bool {
+		return u.Identifier == user || u.EmailAddress == user
+	})
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	ws.users = remove(ws.users, i)
+	w.WriteHeader(http.StatusOK)
+}
+
+func sameUser(identifier string) func(types.GroupUser) bool {
+	return func(u types.GroupUser) bool {
+		return u.Identifier == identifier
+	}
+}
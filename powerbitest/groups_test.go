@@ -0,0 +1,92 @@
+package powerbitest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/powerbitest"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestGroups(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+
+	g, err := pbi.Groups.Create(ctx, types.CreateGroupRequest{Name: "sales"}, types.CreateGroupOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.ID == "" || g.Name != "sales" {
+		t.Errorf("Create() = %+v", g)
+	}
+	if _, err := pbi.Groups.Create(ctx, types.CreateGroupRequest{Name: "sales"}, types.CreateGroupOptions{}); !types.IsConflict(err) {
+		t.Errorf("Create() of a duplicate name: error %v, want 409", err)
+	}
+	srv.AddGroup("finance")
+	srv.AddGroup("hr")
+
+	groups, err := pbi.Groups.List(ctx, types.ListGroupsOptions{})
+	if err != nil || len(groups) != 3 {
+		t.Fatalf("List() = %v, %v, want 3 groups", groups, err)
+	}
+	groups, err = pbi.Groups.List(ctx, types.ListGroupsOptions{Skip: 1, Top: 1})
+	if err != nil || len(groups) != 1 || groups[0].Name != "finance" {
+		t.Errorf("List($skip=1, $top=1) = %v, %v, want finance", groups, err)
+	}
+
+	updated, err := pbi.Groups.Update(ctx, g.ID, types.UpdateGroupRequest{Name: "sales eu"})
+	if err != nil || updated.Name != "sales eu" {
+		t.Errorf("Update() = %v, %v", updated, err)
+	}
+	if got, err := pbi.Groups.Get(ctx, g.ID); err != nil || got.Name != "sales eu" {
+		t.Errorf("Get() = %v, %v", got, err)
+	}
+
+	if err := pbi.Groups.Delete(ctx, g.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pbi.Groups.Get(ctx, g.ID); !types.IsNotFound(err) {
+		t.Errorf("Get() of a deleted group: error %v, want 404", err)
+	}
+}
+
+func TestGroupUsers(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+
+	user := types.GroupUser{
+		User:                 types.User{Identifier: "ann@contoso.com", EmailAddress: "ann@contoso.com", PrincipalType: types.PrincipalTypeUser},
+		GroupUserAccessRight: types.GroupUserAccessRightContributor,
+	}
+	if err := pbi.Groups.AddGroupUser(ctx, g.ID, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := pbi.Groups.AddGroupUser(ctx, g.ID, user); err == nil {
+		t.Error("AddGroupUser() of an existing user succeeded")
+	}
+
+	user.GroupUserAccessRight = types.GroupUserAccessRightAdmin
+	if err := pbi.Groups.UpdateGroupUser(ctx, g.ID, user); err != nil {
+		t.Fatal(err)
+	}
+	users, err := pbi.Groups.ListGroupUsers(ctx, g.ID, types.ListGroupUserOptions{})
+	if err != nil || len(users) != 1 || users[0].GroupUserAccessRight != types.GroupUserAccessRightAdmin {
+		t.Errorf("ListGroupUsers() = %v, %v", users, err)
+	}
+	if got := srv.GroupUsers(g.ID); len(got) != 1 {
+		t.Errorf("GroupUsers() = %v", got)
+	}
+
+	if err := pbi.Groups.DeleteGroupUser(ctx, g.ID, "ann@contoso.com", types.DeleteGroupUserOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pbi.Groups.DeleteGroupUser(ctx, g.ID, "ann@contoso.com", types.DeleteGroupUserOptions{}); !types.IsNotFound(err) {
+		t.Errorf("DeleteGroupUser() of a removed user: error %v, want 404", err)
+	}
+	if _, err := pbi.Groups.ListGroupUsers(ctx, "missing", types.ListGroupUserOptions{}); !types.IsNotFound(err) {
+		t.Errorf("ListGroupUsers() of a missing group: error %v, want 404", err)
+	}
+}
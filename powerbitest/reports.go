@@ -0,0 +1,143 @@
+package powerbitest
+
+import (
+	"net/http"
+
+	"github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func (s *Server) registerReports(mux *http.ServeMux) {
+	s.handleBoth(mux, http.MethodGet, "/reports", s.listReports)
+	s.handleBoth(mux, http.MethodGet, "/reports/{reportId}", s.getReport)
+	s.handleBoth(mux, http.MethodDelete, "/reports/{reportId}", s.deleteReport)
+	s.handleBoth(mux, http.MethodPost, "/reports/{reportId}/Clone", s.cloneReport)
+	s.handleBoth(mux, http.MethodPost, "/reports/{reportId}/Rebind", s.rebindReport)
+	s.handleBoth(mux, http.MethodGet, "/reports/{reportId}/pages", s.listPages)
+	s.handleBoth(mux, http.MethodGet, "/reports/{reportId}/pages/{pageName}", s.getPage)
+}
+
+// AddReport adds a report with the given pages to the specified workspace,
+// or to My workspace if groupID is empty, and returns it. An ID is generated
+// if r has none.
+func (s *Server) AddReport(groupID string, r types.Report, pages ...types.Page) types.Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ws := s.mustWorkspace(groupID)
+	if r.ID == "" {
+		r.ID = newID()
+	}
+	if r.ReportType == "" {
+		r.ReportType = types.ReportTypePowerBI
+	}
+	if r.EmbedURL == "" {
+		r.EmbedURL = powerbi.CloudPublic.ReportEmbedURL(groupID, r.ID)
+	}
+	ws.reports = append(ws.reports, &report{Report: r, pages: pages})
+	return r
+}
+
+// reportFor returns the report addressed by r, writing a 404 error if it does
+// not exist. s.mu must be held.
+func (s *Server) reportFor(w http.ResponseWriter, r *http.Request) (*workspace, *report) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return nil, nil
+	}
+	rep, _ := find(ws.reports, func(rep *report) bool { return rep.ID == r.PathValue("reportId") })
+	if rep == nil {
+		writeNotFound(w)
+	}
+	return ws, rep
+}
+
+func (s *Server) listReports(w http.ResponseWriter, r *http.Request) {
+	ws := s.workspaceFor(w, r)
+	if ws == nil {
+		return
+	}
+	reports := []types.Report{}
+	for _, rep := range ws.reports {
+		reports = append(reports, rep.Report)
+	}
+	writeJSON(w, http.StatusOK, types.ReportList{Value: reports})
+}
+
+func (s *Server) getReport(w http.ResponseWriter, r *http.Request) {
+	if _, rep := s.reportFor(w, r); rep != nil {
+		writeJSON(w, http.StatusOK, rep.Report)
+	}
+}
+
+func (s *Server) deleteReport(w http.ResponseWriter, r *http.Request) {
+	ws, rep := s.reportFor(w, r)
+	if rep == nil {
+		return
+	}
+	_, i := find(ws.reports, func(other *report) bool { return other == rep })
+	ws.reports = remove(ws.reports, i)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) cloneReport(w http.ResponseWriter, r *http.Request) {
+	ws, rep := s.reportFor(w, r)
+	if rep == nil {
+		return
+	}
+	var req types.CloneReportRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	target := ws
+	if req.TargetWorkspaceID != "" {
+		if target = s.workspace(req.TargetWorkspaceID); target == nil {
+			writeNotFound(w)
+			return
+		}
+	}
+	clone := &report{Report: rep.Report, pages: append([]types.Page(nil), rep.pages...)}
+	clone.ID = newID()
+	clone.Name = req.Name
+	clone.OriginalReportID = rep.ID
+	clone.EmbedURL = powerbi.CloudPublic.ReportEmbedURL(target.group.ID, clone.ID)
+	clone.Users = nil
+	clone.Subscriptions = nil
+	if req.TargetModelID != "" {
+		clone.DatasetID = req.TargetModelID
+	}
+	target.reports = append(target.reports, clone)
+	writeJSON(w, http.StatusOK, clone.Report)
+}
+
+func (s *Server) rebindReport(w http.ResponseWriter, r *http.Request) {
+	_, rep := s.reportFor(w, r)
+	if rep == nil {
+		return
+	}
+	var req types.RebindReportRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	rep.DatasetID = req.DatasetID
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listPages(w http.ResponseWriter, r *http.Request) {
+	if _, rep := s.reportFor(w, r); rep != nil {
+		writeJSON(w, http.StatusOK, types.PageList{Value: append([]types.Page{}, rep.pages...)})
+	}
+}
+
+func (s *Server) getPage(w http.ResponseWriter, r *http.Request) {
+	_, rep := s.reportFor(w, r)
+	if rep == nil {
+		return
+	}
+	p, i := find(rep.pages, func(p types.Page) bool { return p.Name == r.PathValue("pageName") })
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
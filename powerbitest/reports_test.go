@@ -0,0 +1,83 @@
+package powerbitest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/powerbitest"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestReports(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+
+	mine := srv.AddReport("", types.Report{Name: "mine"})
+	r := srv.AddReport(g.ID, types.Report{Name: "revenue", DatasetID: "ds1"},
+		types.Page{Name: "ReportSection1", DisplayName: "Overview"},
+		types.Page{Name: "ReportSection2", DisplayName: "Details", Order: 1},
+	)
+
+	reports, err := pbi.Reports.List(ctx)
+	if err != nil || len(reports) != 1 || reports[0].ID != mine.ID {
+		t.Errorf("List() = %v, %v, want the My workspace report", reports, err)
+	}
+	reports, err = pbi.Reports.ListInGroup(ctx, g.ID)
+	if err != nil || len(reports) != 1 || reports[0].ID != r.ID {
+		t.Errorf("ListInGroup() = %v, %v, want the workspace report", reports, err)
+	}
+	if got, err := pbi.Reports.GetInGroup(ctx, g.ID, r.ID); err != nil || got.Name != "revenue" || got.EmbedURL == "" {
+		t.Errorf("GetInGroup() = %v, %v", got, err)
+	}
+	if _, err := pbi.Reports.Get(ctx, r.ID); !types.IsNotFound(err) {
+		t.Errorf("Get() of a workspace report from My workspace: error %v, want 404", err)
+	}
+
+	pages, err := pbi.Reports.ListPagesInGroup(ctx, g.ID, r.ID)
+	if err := checkLen(pages, err, 2); err != nil {
+		t.Errorf("ListPagesInGroup(): %v", err)
+	}
+	if p, err := pbi.Reports.GetPageInGroup(ctx, g.ID, r.ID, "ReportSection2"); err != nil || p.DisplayName != "Details" {
+		t.Errorf("GetPageInGroup() = %v, %v", p, err)
+	}
+	if _, err := pbi.Reports.GetPageInGroup(ctx, g.ID, r.ID, "missing"); !types.IsNotFound(err) {
+		t.Errorf("GetPageInGroup() of a missing page: error %v, want 404", err)
+	}
+
+	clone, err := pbi.Reports.CloneInGroup(ctx, g.ID, r.ID, types.CloneReportRequest{Name: "copy"})
+	if err != nil || clone.ID == r.ID || clone.OriginalReportID != r.ID || clone.DatasetID != "ds1" {
+		t.Errorf("CloneInGroup() = %v, %v", clone, err)
+	}
+	if _, err := pbi.Reports.Clone(ctx, mine.ID, types.CloneReportRequest{Name: "moved", TargetWorkspaceID: g.ID}); err != nil {
+		t.Fatal(err)
+	}
+	reports, err = pbi.Reports.ListInGroup(ctx, g.ID)
+	if err := checkLen(reports, err, 3); err != nil {
+		t.Errorf("ListInGroup() after cloning: %v", err)
+	}
+
+	if err := pbi.Reports.RebindInGroup(ctx, g.ID, r.ID, types.RebindReportRequest{DatasetID: "ds2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := pbi.Reports.GetInGroup(ctx, g.ID, r.ID); err != nil || got.DatasetID != "ds2" {
+		t.Errorf("GetInGroup() after rebinding = %v, %v", got, err)
+	}
+
+	if err := pbi.Reports.DeleteInGroup(ctx, g.ID, r.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pbi.Reports.GetInGroup(ctx, g.ID, r.ID); !types.IsNotFound(err) {
+		t.Errorf("GetInGroup() of a deleted report: error %v, want 404", err)
+	}
+}
+
+// checkLen returns err, or an error if list does not have n elements.
+func checkLen[T any](list []T, err error, n int) error {
+	if err == nil && len(list) != n {
+		err = fmt.Errorf("got %d elements, want %d", len(list), n)
+	}
+	return err
+}
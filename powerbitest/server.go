@@ -0,0 +1,326 @@
+// Package powerbitest provides an in-memory fake of the Power BI REST APIs
+// for testing code that uses the powerbi package.
+//
+// The fake is stateful: workspaces and their users, reports and pages,
+// dashboards and tiles, and push datasets with tables and rows can be created
+// through the API or seeded with the Add methods, and later read back.
+// ExecuteQueries answers with results scripted by ScriptQuery and
+// GenerateToken issues opaque embed tokens.
+//
+//	srv := powerbitest.New(t)
+//	pbi := srv.Client()
+//	g, err := pbi.Groups.Create(ctx, types.CreateGroupRequest{Name: "test"}, types.CreateGroupOptions{})
+//
+// Faults can be injected per route with InjectError, Throttle and SetLatency.
+// Routes are named after their method and path pattern, relative to the API
+// base URL, such as "GET /groups/{groupId}/reports/{reportId}". My workspace
+// routes are named without the "/groups/{groupId}" prefix, and the "*" route
+// matches every request.
+package powerbitest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// AnyRoute matches every route in InjectError, Throttle and SetLatency.
+const AnyRoute = "*"
+
+// Server is a fake Power BI REST API server.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	workspaces []*workspace
+	queries    map[queryKey]types.DatasetExecuteQueriesQueryResult
+	faults     []*fault
+	latency    map[string]time.Duration
+}
+
+// workspace holds the content of a workspace. My workspace is the workspace
+// with an empty ID.
+type workspace struct {
+	group      types.Group
+	users      []types.GroupUser
+	reports    []*report
+	dashboards []*dashboard
+	datasets   []*dataset
+}
+
+type report struct {
+	types.Report
+	pages []types.Page
+}
+
+type dashboard struct {
+	types.Dashboard
+	tiles []types.Tile
+}
+
+type dataset struct {
+	types.Dataset
+	tables []*table
+}
+
+type table struct {
+	types.Table
+	rows []map[string]any
+}
+
+type queryKey struct {
+	datasetID string
+	query     string
+}
+
+type fault struct {
+	route      string
+	status     int
+	code       string
+	retryAfter time.Duration
+	remaining  int
+}
+
+// NewServer starts a new fake Power BI server. The caller must call Close
+// when done with it.
+func NewServer() *Server {
+	s := &Server{
+		workspaces: []*workspace{{}},
+		queries:    map[queryKey]types.DatasetExecuteQueriesQueryResult{},
+		latency:    map[string]time.Duration{},
+	}
+
+	mux := http.NewServeMux()
+	s.registerGroups(mux)
+	s.registerReports(mux)
+	s.registerDashboards(mux)
+	s.registerDatasets(mux)
+	s.registerEmbedToken(mux)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// New starts a new fake Power BI server that is closed when tb finishes.
+func New(tb testing.TB) *Server {
+	tb.Helper()
+	s := NewServer()
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a new client pointed at the server. Its retry policy keeps
+// the default number of attempts with millisecond backoffs, so that injected
+// throttling and transient errors are retried without slowing tests down.
+func (s *Server) Client() *powerbi.Client {
+	c := powerbi.NewClient(s.Server.Client())
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	c.RetryPolicy = powerbi.DefaultRetryPolicy()
+	c.RetryPolicy.MinBackoff = time.Millisecond
+	c.RetryPolicy.MaxBackoff = 10 * time.Millisecond
+	return c
+}
+
+// InjectError makes the next times requests to route fail with the given
+// status and Power BI error code. A times of zero or less fails every request
+// until ClearFaults is called.
+func (s *Server) InjectError(route string, status int, code string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{route: route, status: status, code: code, remaining: times})
+}
+
+// Throttle makes the next times requests to route fail with 429 Too Many
+// Requests and the given Retry-After delay. A times of zero or less throttles
+// every request until ClearFaults is called.
+func (s *Server) Throttle(route string, retryAfter time.Duration, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{
+		route:      route,
+		status:     http.StatusTooManyRequests,
+		code:       "TooManyRequests",
+		retryAfter: retryAfter,
+		remaining:  times,
+	})
+}
+
+// SetLatency delays every response of route by d.
+func (s *Server) SetLatency(route string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[route] = d
+}
+
+// ClearFaults removes all injected errors, throttling and latency.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+	s.latency = map[string]time.Duration{}
+}
+
+// handle registers h for route on mux, applying the injected faults of route.
+func (s *Server) handle(mux *http.ServeMux, route string, h func(w http.ResponseWriter, r *http.Request)) {
+	mux.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RequestId", newID())
+
+		s.mu.Lock()
+		delay := s.latency[route] + s.latency[AnyRoute]
+		f := s.takeFault(route)
+		s.mu.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if f != nil {
+			if f.retryAfter > 0 || f.status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", strconv.Itoa(int(f.retryAfter.Seconds())))
+			}
+			writeError(w, f.status, f.code, "injected fault")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		h(w, r)
+	})
+}
+
+// handleBoth registers h for the My workspace route and the workspace route
+// of method and path.
+func (s *Server) handleBoth(mux *http.ServeMux, method, path string, h func(w http.ResponseWriter, r *http.Request)) {
+	s.handle(mux, method+" "+path, h)
+	s.handle(mux, method+" /groups/{groupId}"+path, h)
+}
+
+// takeFault returns the fault to apply to a request of route, if any.
+// s.mu must be held.
+func (s *Server) takeFault(route string) *fault {
+	for i, f := range s.faults {
+		if f.route != route && f.route != AnyRoute {
+			continue
+		}
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// workspaceFor returns the workspace addressed by r, writing a 404 error if
+// it does not exist. s.mu must be held.
+func (s *Server) workspaceFor(w http.ResponseWriter, r *http.Request) *workspace {
+	ws := s.workspace(r.PathValue("groupId"))
+	if ws == nil {
+		writeNotFound(w)
+	}
+	return ws
+}
+
+// workspace returns the workspace with the given ID. s.mu must be held.
+func (s *Server) workspace(groupID string) *workspace {
+	for _, ws := range s.workspaces {
+		if ws.group.ID == groupID {
+			return ws
+		}
+	}
+	return nil
+}
+
+// mustWorkspace returns the workspace with the given ID, panicking if it does
+// not exist. It is used by the seeding methods. s.mu must be held.
+func (s *Server) mustWorkspace(groupID string) *workspace {
+	ws := s.workspace(groupID)
+	if ws == nil {
+		panic(fmt.Sprintf("powerbitest: workspace %q does not exist", groupID))
+	}
+	return ws
+}
+
+// newID returns a random GUID.
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message,omitempty"`
+		} `json:"error"`
+	}
+	body.Error.Code = code
+	body.Error.Message = message
+	writeJSON(w, status, body)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "PowerBIEntityNotFound", "")
+}
+
+// decode decodes the JSON body of r into v, writing a 400 error on failure.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return false
+	}
+	return true
+}
+
+// page applies the $skip and $top query parameters of r to items.
+func page[T any](r *http.Request, items []T) []T {
+	q := r.URL.Query()
+	if skip, err := strconv.Atoi(q.Get("$skip")); err == nil && skip > 0 {
+		items = items[min(skip, len(items)):]
+	}
+	if top, err := strconv.Atoi(q.Get("$top")); err == nil && top >= 0 {
+		items = items[:min(top, len(items))]
+	}
+	return items
+}
+
+// find returns the first item for which match returns true.
+func find[T any](items []T, match func(T) bool) (T, int) {
+	for i, v := range items {
+		if match(v) {
+			return v, i
+		}
+	}
+	var zero T
+	return zero, -1
+}
+
+// remove returns items without the item at index i.
+func remove[T any](items []T, i int) []T {
+	return append(items[:i], items[i+1:]...)
+}
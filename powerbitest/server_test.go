@@ -0,0 +1,84 @@
+package powerbitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/powerbitest"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestInjectError(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+	g := srv.AddGroup("sales")
+
+	srv.InjectError("GET /groups/{groupId}", http.StatusForbidden, "PowerBINotAuthorizedException", 1)
+	_, err := pbi.Groups.Get(ctx, g.ID)
+	if !types.IsForbidden(err) || !types.HasErrorCode(err, "PowerBINotAuthorizedException") {
+		t.Fatalf("Get() error = %v, want the injected 403", err)
+	}
+	if _, err := pbi.Groups.Get(ctx, g.ID); err != nil {
+		t.Fatalf("Get() after the injected error: %v", err)
+	}
+
+	srv.InjectError(powerbitest.AnyRoute, http.StatusNotFound, "PowerBIEntityNotFound", 0)
+	for range 2 {
+		if _, err := pbi.Reports.List(ctx); !types.IsNotFound(err) {
+			t.Fatalf("List() error = %v, want 404 until ClearFaults", err)
+		}
+	}
+	srv.ClearFaults()
+	if _, err := pbi.Reports.List(ctx); err != nil {
+		t.Fatalf("List() after ClearFaults: %v", err)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+	ctx := context.Background()
+
+	// Throttled requests are retried by the client.
+	srv.Throttle("GET /reports", 0, 2)
+	if _, err := pbi.Reports.List(ctx); err != nil {
+		t.Fatalf("List() with 2 throttled attempts: %v", err)
+	}
+
+	srv.Throttle("GET /reports", 0, 0)
+	if _, err := pbi.Reports.List(ctx); !types.IsThrottled(err) {
+		t.Fatalf("List() error = %v, want 429", err)
+	}
+}
+
+func TestSetLatency(t *testing.T) {
+	srv := powerbitest.New(t)
+	pbi := srv.Client()
+
+	srv.SetLatency("GET /reports", time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := pbi.Reports.List(ctx); err == nil {
+		t.Fatal("List() succeeded despite the latency")
+	}
+
+	srv.ClearFaults()
+	if _, err := pbi.Reports.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	srv := powerbitest.New(t)
+	resp, err := http.Get(srv.URL + "/reports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.Header.Get("RequestId") == "" {
+		t.Error("response has no RequestId header")
+	}
+}
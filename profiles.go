@@ -0,0 +1,149 @@
+package powerbi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+const profilesBasePath = "profiles"
+
+// profileHeader is the header that makes a service principal act as one of
+// its profiles.
+const profileHeader = "X-PowerBI-Profile-Id"
+
+// ProfilesService handles communication with the service principal profiles related methods of the Power BI API.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/profiles
+type ProfilesService service
+
+var _ ProfilesInterface = &ProfilesService{}
+
+// ProfilesInterface is the interface implemented by ProfilesService.
+type ProfilesInterface interface {
+	All(ctx context.Context, opts types.ListProfilesOptions) iter.Seq2[types.ServicePrincipalProfile, error]
+	Create(ctx context.Context, req types.CreateOrUpdateProfileRequest) (*types.ServicePrincipalProfile, error)
+	Delete(ctx context.Context, profileID string) error
+	Get(ctx context.Context, profileID string) (*types.ServicePrincipalProfile, error)
+	List(ctx context.Context, opts types.ListProfilesOptions) ([]types.ServicePrincipalProfile, error)
+	Update(ctx context.Context, profileID string, req types.CreateOrUpdateProfileRequest) error
+}
+
+// Create creates a new service principal profile.
+func (s *ProfilesService) Create(ctx context.Context, req types.CreateOrUpdateProfileRequest) (*types.ServicePrincipalProfile, error) {
+	ctx = withOperation(ctx, "Profiles.Create")
+	u := profilesBasePath
+	_, resp, err := s.client.postJSON(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.ServicePrincipalProfile{})
+}
+
+// Delete deletes the specified service principal profile.
+func (s *ProfilesService) Delete(ctx context.Context, profileID string) error {
+	ctx = withOperation(ctx, "Profiles.Delete")
+	u := fmt.Sprintf("%s/%s", profilesBasePath, url.PathEscape(profileID))
+	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// Get returns the specified service principal profile.
+func (s *ProfilesService) Get(ctx context.Context, profileID string) (*types.ServicePrincipalProfile, error) {
+	ctx = withOperation(ctx, "Profiles.Get")
+	u := fmt.Sprintf("%s/%s", profilesBasePath, url.PathEscape(profileID))
+	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return toObject(resp, &types.ServicePrincipalProfile{})
+}
+
+// List lists the service principal profiles created by the calling service principal,
+// with optional $orderBy, $top and $skip parameters.
+func (s *ProfilesService) List(ctx context.Context, opts types.ListProfilesOptions) ([]types.ServicePrincipalProfile, error) {
+	ctx = withOperation(ctx, "Profiles.List")
+	u := profilesBasePath
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.ServicePrincipalProfileList
+	_, err = toObject(resp, &result)
+
+	return result.Value, err
+}
+
+// All returns an iterator over all service principal profiles, fetching them
+// page by page. opts.Top sets the page size and opts.Skip the initial offset.
+func (s *ProfilesService) All(ctx context.Context, opts types.ListProfilesOptions) iter.Seq2[types.ServicePrincipalProfile, error] {
+	if opts.Top <= 0 {
+		opts.Top = defaultPageSize
+	}
+	return offsetPager(ctx, opts.Top, opts.Skip, func(ctx context.Context, top, skip int) ([]types.ServicePrincipalProfile, error) {
+		opts := opts
+		opts.Top, opts.Skip = top, skip
+		return s.List(ctx, opts)
+	})
+}
+
+// Update updates the display name of the specified service principal profile.
+func (s *ProfilesService) Update(ctx context.Context, profileID string, req types.CreateOrUpdateProfileRequest) error {
+	ctx = withOperation(ctx, "Profiles.Update")
+	u := fmt.Sprintf("%s/%s", profilesBasePath, url.PathEscape(profileID))
+	_, resp, err := s.client.patchJSON(ctx, u, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+type profileKey struct{}
+
+// WithProfileID returns a copy of ctx that makes the requests made with it act
+// as the specified service principal profile. It takes precedence over
+// Client.WithProfile.
+func WithProfileID(ctx context.Context, profileID string) context.Context {
+	return context.WithValue(ctx, profileKey{}, profileID)
+}
+
+// WithProfile returns a copy of the client whose requests act as the specified
+// service principal profile. The copy shares the HTTP client, middleware and
+// settings of c, so it is cheap to create per tenant.
+func (c *Client) WithProfile(profileID string) *Client {
+	clone := *c
+	clone.profileID = profileID
+	clone.middleware = c.middleware[:len(c.middleware):len(c.middleware)]
+	clone.initServices()
+	return &clone
+}
+
+// profileFor returns the service principal profile the requests made with ctx
+// act as.
+func (c *Client) profileFor(ctx context.Context) string {
+	if id, ok := ctx.Value(profileKey{}).(string); ok {
+		return id
+	}
+	return c.profileID
+}
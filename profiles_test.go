@@ -0,0 +1,68 @@
+package powerbi
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestProfileHeader(t *testing.T) {
+	c, mux := setup(t)
+	var profile []string
+	mux.HandleFunc("GET /v1.0/myorg/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		profile = r.Header.Values("X-PowerBI-Profile-Id")
+		w.Write([]byte(`{}`))
+	})
+	tenant := c.WithProfile("p1")
+
+	tests := []struct {
+		name   string
+		client *Client
+		ctx    context.Context
+		want   string
+	}{
+		{"no profile", c, context.Background(), ""},
+		{"client profile", tenant, context.Background(), "p1"},
+		{"context profile", c, WithProfileID(context.Background(), "p2"), "p2"},
+		{"context overrides client", tenant, WithProfileID(context.Background(), "p2"), "p2"},
+		{"context clears client", tenant, WithProfileID(context.Background(), ""), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile = nil
+			if _, err := tt.client.Groups.Get(tt.ctx, "g1"); err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.want == "" && len(profile) > 0:
+				t.Errorf("X-PowerBI-Profile-Id = %q, want no header", profile)
+			case tt.want != "" && (len(profile) != 1 || profile[0] != tt.want):
+				t.Errorf("X-PowerBI-Profile-Id = %q, want %q", profile, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithProfileMiddleware(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	var log []string
+	c.Use(recordMiddleware("shared", &log))
+	tenant := c.WithProfile("p1")
+	tenant.Use(recordMiddleware("tenant", &log))
+
+	// Middleware added to the copy does not leak into the original client.
+	if _, err := c.Groups.Get(context.Background(), "g1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tenant.Groups.Get(context.Background(), "g1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"shared>", "<shared", "shared>", "tenant>", "<tenant", "<shared"}
+	if !slices.Equal(log, want) {
+		t.Errorf("middleware calls = %v, want %v", log, want)
+	}
+}
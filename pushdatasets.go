@@ -13,9 +13,26 @@ import (
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets
 type PushDatasetsService service
 
+var _ PushDatasetsInterface = &PushDatasetsService{}
+
+// PushDatasetsInterface is the interface implemented by PushDatasetsService.
+type PushDatasetsInterface interface {
+	DeleteRows(ctx context.Context, datasetID, tableName string) error
+	DeleteRowsInGroup(ctx context.Context, groupID, datasetID, tableName string) error
+	GetTables(ctx context.Context, datasetID string) ([]types.Table, error)
+	GetTablesInGroup(ctx context.Context, groupID, datasetID string) ([]types.Table, error)
+	PostDataset(ctx context.Context, req types.CreateDatasetRequest, opts types.DatasetOptions) (*types.Dataset, error)
+	PostDatasetInGroup(ctx context.Context, groupID string, req types.CreateDatasetRequest, opts types.DatasetOptions) (*types.Dataset, error)
+	PostRows(ctx context.Context, datasetID, tableName string, req types.PostRowsRequest) error
+	PostRowsInGroup(ctx context.Context, groupID, datasetID, tableName string, req types.PostRowsRequest) error
+	PutTable(ctx context.Context, datasetID, tableName string, req types.Table) (*types.Table, error)
+	PutTableInGroup(ctx context.Context, groupID, datasetID, tableName string, req types.Table) (*types.Table, error)
+}
+
 // DeleteRows deletes all rows from the specified table within the specified dataset from My workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-delete-rows
 func (s *PushDatasetsService) DeleteRows(ctx context.Context, datasetID, tableName string) error {
+	ctx = withOperation(ctx, "PushDatasets.DeleteRows")
 	u := fmt.Sprintf("%s/%s/%s/%s/%s", datasetsBasePath, url.PathEscape(datasetID), "tables", url.PathEscape(tableName), "rows")
 
 	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
@@ -30,6 +47,7 @@ func (s *PushDatasetsService) DeleteRows(ctx context.Context, datasetID, tableNa
 // DeleteRowsInGroup deletes all rows from the specified table within the specified dataset from the specified workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-delete-rows-in-group
 func (s *PushDatasetsService) DeleteRowsInGroup(ctx context.Context, groupID, datasetID, tableName string) error {
+	ctx = withOperation(ctx, "PushDatasets.DeleteRowsInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID), "tables", url.PathEscape(tableName), "rows")
 
 	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
@@ -44,6 +62,7 @@ func (s *PushDatasetsService) DeleteRowsInGroup(ctx context.Context, groupID, da
 // GetTables returns a list of tables within the specified dataset from My workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-get-tables
 func (s *PushDatasetsService) GetTables(ctx context.Context, datasetID string) ([]types.Table, error) {
+	ctx = withOperation(ctx, "PushDatasets.GetTables")
 	u := fmt.Sprintf("%s/%s/%s", datasetsBasePath, url.PathEscape(datasetID), "tables")
 
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
@@ -64,6 +83,7 @@ func (s *PushDatasetsService) GetTables(ctx context.Context, datasetID string) (
 // GetTablesInGroup returns a list of tables within the specified dataset from the specified workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-get-tables-in-group
 func (s *PushDatasetsService) GetTablesInGroup(ctx context.Context, groupID, datasetID string) ([]types.Table, error) {
+	ctx = withOperation(ctx, "PushDatasets.GetTablesInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID), "tables")
 
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
@@ -84,6 +104,7 @@ func (s *PushDatasetsService) GetTablesInGroup(ctx context.Context, groupID, dat
 // PostDataset creates a new dataset on My workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-post-dataset
 func (s *PushDatasetsService) PostDataset(ctx context.Context, req types.CreateDatasetRequest, opts types.DatasetOptions) (*types.Dataset, error) {
+	ctx = withOperation(ctx, "PushDatasets.PostDataset")
 	u := datasetsBasePath
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -102,6 +123,7 @@ func (s *PushDatasetsService) PostDataset(ctx context.Context, req types.CreateD
 // PostDatasetInGroup creates a new dataset in the specified workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-post-dataset-in-group
 func (s *PushDatasetsService) PostDatasetInGroup(ctx context.Context, groupID string, req types.CreateDatasetRequest, opts types.DatasetOptions) (*types.Dataset, error) {
+	ctx = withOperation(ctx, "PushDatasets.PostDatasetInGroup")
 	u := fmt.Sprintf("%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath)
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -120,6 +142,7 @@ func (s *PushDatasetsService) PostDatasetInGroup(ctx context.Context, groupID st
 // PostRows adds new data rows to the specified table within the specified dataset from My workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-post-rows
 func (s *PushDatasetsService) PostRows(ctx context.Context, datasetID, tableName string, req types.PostRowsRequest) error {
+	ctx = withOperation(ctx, "PushDatasets.PostRows")
 	u := fmt.Sprintf("%s/%s/%s/%s/%s", datasetsBasePath, url.PathEscape(datasetID), "tables", url.PathEscape(tableName), "rows")
 
 	_, resp, err := s.client.postJSON(ctx, u, req)
@@ -134,6 +157,7 @@ func (s *PushDatasetsService) PostRows(ctx context.Context, datasetID, tableName
 // PostRowsInGroup adds new data rows to the specified table within the specified dataset from the specified workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-post-rows-in-group
 func (s *PushDatasetsService) PostRowsInGroup(ctx context.Context, groupID, datasetID, tableName string, req types.PostRowsRequest) error {
+	ctx = withOperation(ctx, "PushDatasets.PostRowsInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID), "tables", url.PathEscape(tableName), "rows")
 
 	_, resp, err := s.client.postJSON(ctx, u, req)
@@ -148,6 +172,7 @@ func (s *PushDatasetsService) PostRowsInGroup(ctx context.Context, groupID, data
 // PutTable updates the metadata and schema for the specified table within the specified dataset from My workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-put-table
 func (s *PushDatasetsService) PutTable(ctx context.Context, datasetID, tableName string, req types.Table) (*types.Table, error) {
+	ctx = withOperation(ctx, "PushDatasets.PutTable")
 	u := fmt.Sprintf("%s/%s/%s/%s", datasetsBasePath, url.PathEscape(datasetID), "tables", url.PathEscape(tableName))
 
 	_, resp, err := s.client.putJSON(ctx, u, req)
@@ -162,6 +187,7 @@ func (s *PushDatasetsService) PutTable(ctx context.Context, datasetID, tableName
 // PutTableInGroup updates the metadata and schema for the specified table within the specified dataset from the specified workspace.
 // https://learn.microsoft.com/en-us/rest/api/power-bi/push-datasets/datasets-put-table-in-group
 func (s *PushDatasetsService) PutTableInGroup(ctx context.Context, groupID, datasetID, tableName string, req types.Table) (*types.Table, error) {
+	ctx = withOperation(ctx, "PushDatasets.PutTableInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), datasetsBasePath, url.PathEscape(datasetID), "tables", url.PathEscape(tableName))
 
 	_, resp, err := s.client.putJSON(ctx, u, req)
@@ -0,0 +1,249 @@
+package powerbi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/dax"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+const (
+	// maxQueryRows and maxQueryValues are the ExecuteQueries limits per
+	// query, beyond which results are truncated.
+	maxQueryRows   = 100_000
+	maxQueryValues = 1_000_000
+
+	// defaultWindowSize is the number of rows per query of QueryRows when
+	// the caller sets none.
+	defaultWindowSize = 50_000
+
+	// defaultQueriesPerMinute is the ExecuteQueries rate limit per user.
+	defaultQueriesPerMinute = 120
+)
+
+// WindowedQuery is a DAX table expression read by QueryRows in windows of
+// rows ordered by a key.
+type WindowedQuery struct {
+	// Table is the table expression to read, such as dax.Table("Sales") or
+	// a dax.SummarizeColumns call.
+	Table dax.Expr
+
+	// Key names the columns that order the rows of Table as they are named
+	// in the result rows, such as "Sales[OrderID]" or "[Total]". Each window
+	// starts after the key of the last row of the previous one, so rows that
+	// share a key are returned in the same window, and the key should be
+	// unique. Key columns should never be blank. Key values formatted as
+	// date times, such as "2024-01-31T00:00:00", are compared as date times.
+	// Integer keys must be within ±2^53, the integers that JSON numbers
+	// decode to exactly.
+	Key []string
+
+	// WindowSize is the number of rows per query. It defaults to 50,000 and
+	// is reduced as needed to keep each query under the ExecuteQueries
+	// limits of 100,000 rows and 1,000,000 values.
+	WindowSize int
+
+	// QueriesPerMinute caps the rate of the queries. It defaults to 120, the
+	// ExecuteQueries limit per user.
+	QueriesPerMinute int
+
+	// ImpersonatedUserName is sent with each query as in
+	// types.DatasetExecuteQueriesRequest.
+	ImpersonatedUserName string
+
+	// IncludeNulls keeps the columns of null values in the rows. Queries
+	// always include nulls, to size windows by the number of columns of the
+	// table, and they are removed from the rows unless IncludeNulls is set.
+	IncludeNulls bool
+}
+
+// QueryRows returns an iterator over the rows of q.Table in the specified dataset from the specified workspace. It
+// pages through the table with one ExecuteQueries call per window of q.WindowSize rows, in key order, spacing the
+// calls to respect q.QueriesPerMinute. A window whose result reaches the ExecuteQueries limits is retried with
+// fewer rows; if it cannot be reduced, for instance because too many rows share a key, the iterator stops with a
+// *types.QueryTruncatedError.
+func (s *datasetGroupService) QueryRows(ctx context.Context, groupID, datasetID string, q WindowedQuery) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		if q.Table == nil || len(q.Key) == 0 {
+			yield(nil, errors.New("powerbi: windowed query needs a table and a key"))
+			return
+		}
+		size := q.WindowSize
+		if size <= 0 {
+			size = defaultWindowSize
+		}
+		size = min(size, maxQueryRows-1)
+		perMinute := q.QueriesPerMinute
+		if perMinute <= 0 {
+			perMinute = defaultQueriesPerMinute
+		}
+		interval := time.Minute / time.Duration(perMinute)
+
+		var (
+			last []dax.Expr
+			next time.Time
+		)
+		for {
+			if err := sleepUntil(ctx, next); err != nil {
+				yield(nil, err)
+				return
+			}
+			next = time.Now().Add(interval)
+
+			query, err := q.window(size, last)
+			if err != nil {
+				yield(nil, fmt.Errorf("powerbi: %w", err))
+				return
+			}
+			rows, columns, err := s.queryWindow(ctx, groupID, datasetID, q, query)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if mayBeTruncated(len(rows), columns) {
+				if smaller := windowFor(columns, size/2); smaller >= 1 && smaller < size {
+					size = smaller
+					continue
+				}
+				yield(nil, &types.QueryTruncatedError{Query: query, Rows: len(rows), Values: len(rows) * columns})
+				return
+			}
+			for _, row := range rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if len(rows) < size {
+				return
+			}
+			if last, err = q.keyOf(rows[len(rows)-1]); err != nil {
+				yield(nil, err)
+				return
+			}
+			size = windowFor(columns, size)
+		}
+	}
+}
+
+// queryWindow runs the query of a window and returns its rows and the number
+// of columns of the widest row, null values included.
+func (s *datasetGroupService) queryWindow(ctx context.Context, groupID, datasetID string, q WindowedQuery, query string) ([]map[string]any, int, error) {
+	resp, err := s.ExecuteQueries(ctx, groupID, datasetID, types.DatasetExecuteQueriesRequest{
+		ImpersonatedUserName: q.ImpersonatedUserName,
+		Queries:              []types.DatasetExecuteQueriesQuery{{Query: query}},
+		SerializerSettings:   types.DatasetExecuteQueriesSerializationSettings{IncludeNulls: true},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(resp.Results) == 0 || len(resp.Results[0].Tables) == 0 {
+		return nil, 0, nil
+	}
+
+	table := resp.Results[0].Tables[0]
+	rows := make([]map[string]any, len(table.Rows))
+	columns := 0
+	for i, row := range table.Rows {
+		m, ok := row.(map[string]any)
+		if !ok {
+			return nil, 0, fmt.Errorf("powerbi: row %d of the query result is a %T, not an object", i, row)
+		}
+		rows[i] = m
+		columns = max(columns, len(m))
+		if !q.IncludeNulls {
+			maps.DeleteFunc(m, func(_ string, v any) bool { return v == nil })
+		}
+	}
+	return rows, columns, nil
+}
+
+// mayBeTruncated reports whether a result of the given number of rows and
+// columns reached the ExecuteQueries limits, that is, whether it could not
+// hold another row.
+func mayBeTruncated(rows, columns int) bool {
+	return rows >= maxQueryRows || (rows+1)*columns > maxQueryValues
+}
+
+// windowFor returns size, reduced to keep windows of rows of the given number
+// of columns under the ExecuteQueries limits.
+func windowFor(columns, size int) int {
+	if columns > 0 {
+		size = min(size, maxQueryValues/columns-1)
+	}
+	return min(size, maxQueryRows-1)
+}
+
+// window returns the DAX query of the window of size rows after the key
+// values last, or of the first window if last is nil.
+func (q *WindowedQuery) window(size int, last []dax.Expr) (string, error) {
+	keys := make([]dax.Expr, len(q.Key))
+	order := make([]dax.Order, len(q.Key))
+	for i, k := range q.Key {
+		keys[i] = dax.ParseColumn(k)
+		order[i] = dax.Asc(keys[i])
+	}
+
+	table := q.Table
+	if last != nil {
+		// Rows whose key sorts after last: (k1 > v1) || (k1 = v1 && k2 > v2)...
+		after := make([]dax.Expr, len(keys))
+		for i := range keys {
+			conds := make([]dax.Expr, 0, i+1)
+			for j := range i {
+				conds = append(conds, dax.Eq(keys[j], last[j]))
+			}
+			after[i] = dax.And(append(conds, dax.Gt(keys[i], last[i]))...)
+		}
+		table = dax.Filter(table, dax.Or(after...))
+	}
+	return dax.NewQuery().Evaluate(dax.TopN(size, table, order...), order...).Build()
+}
+
+// rowTimeLayout is the layout of the date times of ExecuteQueries results.
+const rowTimeLayout = "2006-01-02T15:04:05.999999999"
+
+// keyOf returns the key values of row as DAX literals.
+func (q *WindowedQuery) keyOf(row map[string]any) ([]dax.Expr, error) {
+	key := make([]dax.Expr, len(q.Key))
+	for i, name := range q.Key {
+		v, ok := row[name]
+		if !ok || v == nil {
+			return nil, fmt.Errorf("powerbi: key column %q is not in the query result, or is blank", name)
+		}
+		switch x := v.(type) {
+		case string:
+			if t, err := time.ParseInLocation(rowTimeLayout, x, time.UTC); err == nil {
+				v = t
+			}
+		case float64:
+			if x == math.Trunc(x) && math.Abs(x) >= 1<<53 {
+				return nil, fmt.Errorf("powerbi: key column %q value %v is beyond the integers JSON numbers decode to exactly", name, x)
+			}
+		}
+		key[i] = dax.Value(v)
+	}
+	return key, nil
+}
+
+// sleepUntil waits until t or until ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package powerbi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/dax"
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// serveWindows serves ExecuteQueries with the given results, one per query,
+// and records the requests.
+func serveWindows(t *testing.T, mux *http.ServeMux, results ...[]map[string]any) *[]types.DatasetExecuteQueriesRequest {
+	var reqs []types.DatasetExecuteQueriesRequest
+	mux.HandleFunc("POST /v1.0/myorg/groups/g1/datasets/d1/executeQueries", func(w http.ResponseWriter, r *http.Request) {
+		var req types.DatasetExecuteQueriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		if len(reqs) >= len(results) {
+			t.Errorf("unexpected query %d: %s", len(reqs), req.Queries[0].Query)
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		rows := results[len(reqs)]
+		reqs = append(reqs, req)
+		json.NewEncoder(w).Encode(map[string]any{
+			"results": []any{map[string]any{"tables": []any{map[string]any{"rows": rows}}}},
+		})
+	})
+	return &reqs
+}
+
+func TestQueryRows(t *testing.T) {
+	c, mux := setup(t)
+	reqs := serveWindows(t, mux,
+		[]map[string]any{
+			{"Sales[OrderID]": 1.0, "Sales[Note]": "a"},
+			{"Sales[OrderID]": 2.0, "Sales[Note]": nil},
+		},
+		[]map[string]any{
+			{"Sales[OrderID]": 3.0, "Sales[Note]": nil},
+		},
+	)
+
+	var got []map[string]any
+	for row, err := range c.Datasets.Group().QueryRows(context.Background(), "g1", "d1", WindowedQuery{
+		Table:            dax.Table("Sales"),
+		Key:              []string{"Sales[OrderID]"},
+		WindowSize:       2,
+		QueriesPerMinute: 1_000_000,
+	}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, row)
+	}
+
+	want := []map[string]any{
+		{"Sales[OrderID]": 1.0, "Sales[Note]": "a"},
+		{"Sales[OrderID]": 2.0},
+		{"Sales[OrderID]": 3.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+	if len(*reqs) != 2 {
+		t.Fatalf("%d queries, want 2", len(*reqs))
+	}
+	for i, req := range *reqs {
+		if !req.SerializerSettings.IncludeNulls {
+			t.Errorf("query %d does not include nulls", i)
+		}
+	}
+	if q := (*reqs)[1].Queries[0].Query; !strings.Contains(q, "'Sales'[OrderID] > 2") {
+		t.Errorf("second query %q does not start after key 2", q)
+	}
+}
+
+func TestQueryRowsIncludeNulls(t *testing.T) {
+	c, mux := setup(t)
+	serveWindows(t, mux, []map[string]any{{"Sales[OrderID]": 1.0, "Sales[Note]": nil}})
+
+	for row, err := range c.Datasets.Group().QueryRows(context.Background(), "g1", "d1", WindowedQuery{
+		Table:        dax.Table("Sales"),
+		Key:          []string{"Sales[OrderID]"},
+		IncludeNulls: true,
+	}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := row["Sales[Note]"]; !ok {
+			t.Errorf("row %v has no null Sales[Note]", row)
+		}
+	}
+}
+
+func TestQueryRowsRejectsImpreciseKeys(t *testing.T) {
+	c, mux := setup(t)
+	serveWindows(t, mux, []map[string]any{{"Sales[OrderID]": 1 << 53}})
+
+	var err error
+	for _, err = range c.Datasets.Group().QueryRows(context.Background(), "g1", "d1", WindowedQuery{
+		Table:      dax.Table("Sales"),
+		Key:        []string{"Sales[OrderID]"},
+		WindowSize: 1,
+	}) {
+		if err != nil {
+			break
+		}
+	}
+	if err == nil || !strings.Contains(err.Error(), "Sales[OrderID]") {
+		t.Errorf("err = %v, want an error about the key", err)
+	}
+}
+
+func TestMayBeTruncated(t *testing.T) {
+	tests := []struct {
+		rows, columns int
+		want          bool
+	}{
+		{0, 0, false},
+		{99_999, 1, false},
+		{100_000, 1, true},
+		{99_999, 10, false},
+		{99_999, 11, true},
+		{windowFor(7, maxQueryRows), 7, false},
+		{500_000, 2, true},
+	}
+	for _, tt := range tests {
+		if got := mayBeTruncated(tt.rows, tt.columns); got != tt.want {
+			t.Errorf("mayBeTruncated(%d, %d) = %v, want %v", tt.rows, tt.columns, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// Package recorder records Power BI API interactions to cassette files and
+// replays them, for deterministic integration tests that run offline.
+//
+// A Recorder is an http.RoundTripper. In record mode it sends requests
+// through its Transport and records each request and response; in replay
+// mode it answers requests with the recorded responses, without touching the
+// network:
+//
+//	rec, err := recorder.New("testdata/reports.json", recorder.ModeReplayOrRecord)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//	rec.Transport = &powerbi.TokenTransport{Provider: provider}
+//	pbi := powerbi.NewClient(rec.Client())
+//
+// Recorded interactions never contain Authorization headers, cookies, access
+// or embed tokens, client secrets or data source credentials. Tenant IDs are
+// replaced with ZeroID in token endpoint paths and in tenantId and tid JSON
+// fields. Other tenant-specific values, such as workspace IDs or the tenant
+// IDs of embed URLs, can be replaced with placeholders through Replace.
+//
+// Replayed requests match recorded ones on method, path, query and body,
+// where JSON bodies are compared regardless of formatting and field order.
+// Each recorded interaction is replayed once, in order.
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay answers requests with recorded responses and fails
+	// requests that were not recorded.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests and records them, overwriting the cassette.
+	ModeRecord
+
+	// ModeReplayOrRecord replays the cassette if it exists and records it
+	// otherwise.
+	ModeReplayOrRecord
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeReplayOrRecord:
+		return "replay-or-record"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+// It is safe for concurrent use.
+type Recorder struct {
+	// Transport sends requests in record mode. It defaults to
+	// http.DefaultTransport. To record authenticated requests, set it to a
+	// powerbi.TokenTransport.
+	Transport http.RoundTripper
+
+	// Replace maps values, such as workspace and dataset IDs, to the
+	// placeholders that replace them in recorded URLs, headers and bodies.
+	// Replayed requests are matched after the same replacements, so tests
+	// can use either the real values or the placeholders. Set it before the
+	// first request.
+	Replace map[string]string
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	scrubber *scrubber
+	cassette *Cassette
+	used     []bool
+}
+
+// New returns a Recorder for the cassette file at path. In replay mode the
+// cassette is loaded immediately; in record mode it is written by Stop.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, cassette: &Cassette{}}
+	switch mode {
+	case ModeRecord:
+	case ModeReplay, ModeReplayOrRecord:
+		c, err := Load(path)
+		if errors.Is(err, fs.ErrNotExist) && mode == ModeReplayOrRecord {
+			r.mode = ModeRecord
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.mode = ModeReplay
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	default:
+		return nil, fmt.Errorf("recorder: invalid mode %v", mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode the recorder runs in: ModeRecord or ModeReplay.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an *http.Client that sends requests through r.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the recorded interactions to the cassette file in record mode.
+// It does nothing in replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.scrubber == nil {
+		r.scrubber = newScrubber(r.Replace)
+	}
+	recorded := r.scrubber.request(req, body)
+	r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, body, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: r.scrubber.response(resp, respBody),
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var closest *Interaction
+	reason := "the cassette has no interactions left"
+	for i := range r.cassette.Interactions {
+		if r.used[i] {
+			continue
+		}
+		in := &r.cassette.Interactions[i]
+		diff := compare(recorded, in.Request)
+		if diff == "" {
+			r.used[i] = true
+			return in.response(req), nil
+		}
+		if closest == nil || rank(recorded, in.Request) > rank(recorded, closest.Request) {
+			closest, reason = in, diff
+		}
+	}
+	return nil, &MismatchError{Request: recorded, Closest: closest, Reason: reason}
+}
+
+// MismatchError is returned in replay mode for requests that match no
+// recorded interaction left.
+type MismatchError struct {
+	// Request is the unmatched request, scrubbed as if it were recorded.
+	Request Request
+
+	// Closest is the unused recorded interaction closest to Request, if any.
+	Closest *Interaction
+
+	// Reason describes how Closest differs from Request.
+	Reason string
+}
+
+func (e *MismatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "recorder: no recorded interaction matches %s %s", e.Request.Method, e.Request.URL)
+	if e.Closest != nil {
+		fmt.Fprintf(&b, "; closest is %s %s, which differs in %s", e.Closest.Request.Method, e.Closest.Request.URL, e.Reason)
+	} else {
+		fmt.Fprintf(&b, ": %s", e.Reason)
+	}
+	return b.String()
+}
+
+// compare returns a description of the difference between a and b, or an
+// empty string if they match.
+func compare(a, b Request) string {
+	if a.Method != b.Method {
+		return fmt.Sprintf("method: got %s, recorded %s", a.Method, b.Method)
+	}
+	ua, erra := url.Parse(a.URL)
+	ub, errb := url.Parse(b.URL)
+	if erra != nil || errb != nil {
+		if a.URL != b.URL {
+			return fmt.Sprintf("URL: got %s, recorded %s", a.URL, b.URL)
+		}
+		return ""
+	}
+	if ua.Path != ub.Path {
+		return fmt.Sprintf("path: got %s, recorded %s", ua.Path, ub.Path)
+	}
+	if qa, qb := ua.Query(), ub.Query(); !reflect.DeepEqual(qa, qb) && (len(qa) > 0 || len(qb) > 0) {
+		return fmt.Sprintf("query: got %q, recorded %q", encodeQuery(qa), encodeQuery(qb))
+	}
+	if !equalBodies(a.Body, b.Body) {
+		return fmt.Sprintf("body: got %s, recorded %s", truncate(a.Body), truncate(b.Body))
+	}
+	return ""
+}
+
+// rank scores how close b is to a, for diagnostics.
+func rank(a, b Request) int {
+	score := 0
+	if a.Method == b.Method {
+		score += 4
+	}
+	ua, erra := url.Parse(a.URL)
+	ub, errb := url.Parse(b.URL)
+	if erra == nil && errb == nil && ua.Path == ub.Path {
+		score += 2
+		if ua.RawQuery == ub.RawQuery {
+			score++
+		}
+	}
+	return score
+}
+
+// equalBodies reports whether a and b are equal, comparing JSON bodies
+// regardless of formatting and field order.
+func equalBodies(a, b Body) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	va, oka := decodeJSON(a)
+	vb, okb := decodeJSON(b)
+	return oka && okb && reflect.DeepEqual(va, vb)
+}
+
+func encodeQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// truncate formats body for diagnostics.
+func truncate(body Body) string {
+	const limit = 200
+	if len(body) == 0 {
+		return "no body"
+	}
+	if len(body) > limit {
+		return fmt.Sprintf("%q...", body[:limit])
+	}
+	return fmt.Sprintf("%q", body)
+}
+
+// readBody reads and closes the body of req.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
@@ -0,0 +1,71 @@
+package recorder_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go"
+	"github.com/stpabhi/powerbi-go/recorder"
+)
+
+const tenantID = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+
+func TestRecordScrubsTokenRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"secret-token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	rec, err := recorder.New(path, recorder.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc := &powerbi.ClientCredentials{
+		TenantID:     tenantID,
+		ClientID:     "client",
+		ClientSecret: "secret-client",
+		AuthorityURL: srv.URL,
+		HTTPClient:   rec.Client(),
+	}
+	if _, err := cc.GetToken(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{tenantID, "secret-token", "secret-client"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "/"+recorder.ZeroID+"/oauth2/v2.0/token") {
+		t.Errorf("cassette does not record the token endpoint with ZeroID:\n%s", data)
+	}
+
+	// The recorded token request replays for any tenant.
+	rec, err = recorder.New(path, recorder.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc.HTTPClient = rec.Client()
+	cc.TenantID = "contoso.onmicrosoft.com"
+	tok, err := cc.GetToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != recorder.Redacted {
+		t.Errorf("replayed token = %q, want %q", tok.AccessToken, recorder.Redacted)
+	}
+}
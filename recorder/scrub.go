@@ -0,0 +1,191 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces the secrets scrubbed from recorded interactions.
+const Redacted = "REDACTED"
+
+// ZeroID replaces the tenant IDs scrubbed from recorded interactions.
+const ZeroID = "00000000-0000-0000-0000-000000000000"
+
+// secretHeaders are recorded as Redacted.
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// secretFields are JSON and form fields whose values are recorded as
+// Redacted: access and embed tokens, client secrets and assertions, and data
+// source credentials. Names are compared case-insensitively.
+var secretFields = map[string]bool{
+	"token":            true,
+	"accesstoken":      true,
+	"access_token":     true,
+	"refresh_token":    true,
+	"id_token":         true,
+	"client_secret":    true,
+	"clientsecret":     true,
+	"client_assertion": true,
+	"credentials":      true,
+	"password":         true,
+	"identityblob":     true,
+}
+
+// tenantFields are JSON fields whose values are recorded as ZeroID.
+var tenantFields = map[string]bool{
+	"tenantid": true,
+	"tid":      true,
+}
+
+// scrubber removes secrets and tenant-specific values from requests and
+// responses.
+type scrubber struct {
+	// replacements are the literal replacements applied to URLs, headers
+	// and bodies, longest value first.
+	replacements []string
+}
+
+func newScrubber(replace map[string]string) *scrubber {
+	values := make([]string, 0, len(replace))
+	for v := range replace {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	slices.SortFunc(values, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	s := &scrubber{}
+	for _, v := range values {
+		s.replacements = append(s.replacements, v, replace[v])
+	}
+	return s
+}
+
+// replace applies the literal replacements to v.
+func (s *scrubber) replace(v string) string {
+	if len(s.replacements) == 0 {
+		return v
+	}
+	return strings.NewReplacer(s.replacements...).Replace(v)
+}
+
+// request returns the scrubbed recording of req with the given body.
+func (s *scrubber) request(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		URL:    s.replace(scrubTenant(req.URL)),
+		Header: s.header(req.Header),
+		Body:   s.body(req.Header.Get("Content-Type"), body),
+	}
+}
+
+// response returns the scrubbed recording of resp with the given body.
+func (s *scrubber) response(resp *http.Response, body []byte) Response {
+	return Response{
+		StatusCode: resp.StatusCode,
+		Header:     s.header(resp.Header),
+		Body:       s.body(resp.Header.Get("Content-Type"), body),
+	}
+}
+
+// scrubTenant returns u with the tenant of Microsoft Entra ID endpoint paths,
+// such as /{tenant}/oauth2/v2.0/token, replaced with ZeroID.
+func scrubTenant(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	i := slices.Index(segments, "oauth2")
+	if i < 2 {
+		return u.String()
+	}
+	segments[i-1] = ZeroID
+	scrubbed := *u
+	scrubbed.Path = strings.Join(segments, "/")
+	scrubbed.RawPath = ""
+	return scrubbed.String()
+}
+
+func (s *scrubber) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for k, vs := range h {
+		for _, v := range vs {
+			out[k] = append(out[k], s.replace(v))
+		}
+	}
+	for _, k := range secretHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, Redacted)
+		}
+	}
+	return out
+}
+
+// body scrubs the secret and tenant fields of JSON and form bodies, and
+// applies the literal replacements to every body.
+func (s *scrubber) body(contentType string, body []byte) Body {
+	if len(body) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k := range form {
+				if secretFields[strings.ToLower(k)] {
+					form.Set(k, Redacted)
+				}
+			}
+			body = []byte(form.Encode())
+		}
+	case mediaType == "application/json" || json.Valid(body):
+		if v, ok := decodeJSON(body); ok {
+			if data, err := json.Marshal(scrubJSON(v)); err == nil {
+				body = data
+			}
+		}
+	}
+	return Body(s.replace(string(body)))
+}
+
+// scrubJSON replaces the secret and tenant fields of v.
+func scrubJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			switch key := strings.ToLower(k); {
+			case secretFields[key]:
+				v[k] = Redacted
+			case tenantFields[key]:
+				v[k] = ZeroID
+			default:
+				v[k] = scrubJSON(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = scrubJSON(v[i])
+		}
+	}
+	return v
+}
+
+// decodeJSON decodes data, keeping numbers as written.
+func decodeJSON(data []byte) (any, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+	return v, true
+}
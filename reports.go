@@ -15,11 +15,34 @@ const reportsBasePath = "reports"
 // https://learn.microsoft.com/en-us/rest/api/power-bi/reports
 type ReportsService service
 
+var _ ReportsInterface = &ReportsService{}
+
+// ReportsInterface is the interface implemented by ReportsService.
+type ReportsInterface interface {
+	BindToGateway(ctx context.Context, reportID string, req types.RdlBindToGatewayRequest) error
+	BindToGatewayInGroup(ctx context.Context, groupID, reportID string, req types.RdlBindToGatewayRequest) error
+	Clone(ctx context.Context, reportID string, req types.CloneReportRequest) (*types.Report, error)
+	CloneInGroup(ctx context.Context, groupID, reportID string, req types.CloneReportRequest) (*types.Report, error)
+	Delete(ctx context.Context, reportID string) error
+	DeleteInGroup(ctx context.Context, groupID, reportID string) error
+	Get(ctx context.Context, reportID string) (*types.Report, error)
+	GetInGroup(ctx context.Context, groupID, reportID string) (*types.Report, error)
+	GetPage(ctx context.Context, reportID, pageName string) (*types.Page, error)
+	GetPageInGroup(ctx context.Context, groupID, reportID, pageName string) (*types.Page, error)
+	List(ctx context.Context) ([]types.Report, error)
+	ListInGroup(ctx context.Context, groupID string) ([]types.Report, error)
+	ListPages(ctx context.Context, reportID string) ([]types.Page, error)
+	ListPagesInGroup(ctx context.Context, groupID, reportID string) ([]types.Page, error)
+	Rebind(ctx context.Context, reportID string, req types.RebindReportRequest) error
+	RebindInGroup(ctx context.Context, groupID, reportID string, req types.RebindReportRequest) error
+}
+
 // BindToGateway binds the report's dataset to a gateway in My workspace.
 // POST /reports/{reportId}/Default.BindToGateway
 func (s *ReportsService) BindToGateway(ctx context.Context, reportID string, req types.RdlBindToGatewayRequest) error {
+	ctx = withOperation(ctx, "Reports.BindToGateway")
 	u := fmt.Sprintf("%s/%s/Default.BindToGateway", reportsBasePath, url.PathEscape(reportID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return err
 	}
@@ -31,8 +54,9 @@ func (s *ReportsService) BindToGateway(ctx context.Context, reportID string, req
 // BindToGatewayInGroup binds the report's dataset to a gateway in a workspace.
 // POST /groups/{groupId}/reports/{reportId}/Default.BindToGateway
 func (s *ReportsService) BindToGatewayInGroup(ctx context.Context, groupID, reportID string, req types.RdlBindToGatewayRequest) error {
+	ctx = withOperation(ctx, "Reports.BindToGatewayInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/Default.BindToGateway", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return err
 	}
@@ -44,6 +68,7 @@ func (s *ReportsService) BindToGatewayInGroup(ctx context.Context, groupID, repo
 // Clone clones a report from My workspace.
 // POST /reports/{reportId}/Clone
 func (s *ReportsService) Clone(ctx context.Context, reportID string, req types.CloneReportRequest) (*types.Report, error) {
+	ctx = withOperation(ctx, "Reports.Clone")
 	u := fmt.Sprintf("%s/%s/Clone", reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -56,6 +81,7 @@ func (s *ReportsService) Clone(ctx context.Context, reportID string, req types.C
 
 // CloneInGroup clones the specified report from the specified workspace.
 func (s *ReportsService) CloneInGroup(ctx context.Context, groupID, reportID string, req types.CloneReportRequest) (*types.Report, error) {
+	ctx = withOperation(ctx, "Reports.CloneInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/Clone", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.postJSON(ctx, u, req)
 	if err != nil {
@@ -68,6 +94,7 @@ func (s *ReportsService) CloneInGroup(ctx context.Context, groupID, reportID str
 
 // Delete deletes the specified report from My workspace.
 func (s *ReportsService) Delete(ctx context.Context, reportID string) error {
+	ctx = withOperation(ctx, "Reports.Delete")
 	u := fmt.Sprintf("%s/%s", reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
@@ -80,6 +107,7 @@ func (s *ReportsService) Delete(ctx context.Context, reportID string) error {
 
 // DeleteInGroup deletes the specified report from the specified workspace.
 func (s *ReportsService) DeleteInGroup(ctx context.Context, groupID, reportID string) error {
+	ctx = withOperation(ctx, "Reports.DeleteInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
@@ -93,6 +121,7 @@ func (s *ReportsService) DeleteInGroup(ctx context.Context, groupID, reportID st
 // GetPage the specified page within the specified report from My workspace.
 // GET /reports/{reportId}/pages/{pageName}
 func (s *ReportsService) GetPage(ctx context.Context, reportID, pageName string) (*types.Page, error) {
+	ctx = withOperation(ctx, "Reports.GetPage")
 	u := fmt.Sprintf("%s/%s/pages/%s", reportsBasePath, url.PathEscape(reportID), url.PathEscape(pageName))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -105,6 +134,7 @@ func (s *ReportsService) GetPage(ctx context.Context, reportID, pageName string)
 
 // GetPageInGroup the specified page within the specified report from the specified workspace.
 func (s *ReportsService) GetPageInGroup(ctx context.Context, groupID, reportID, pageName string) (*types.Page, error) {
+	ctx = withOperation(ctx, "Reports.GetPageInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/pages/%s", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID), url.PathEscape(pageName))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -118,6 +148,7 @@ func (s *ReportsService) GetPageInGroup(ctx context.Context, groupID, reportID,
 // ListPages returns a list of pages within the specified report from My workspace.
 // GET /reports/{reportId}/pages
 func (s *ReportsService) ListPages(ctx context.Context, reportID string) ([]types.Page, error) {
+	ctx = withOperation(ctx, "Reports.ListPages")
 	u := fmt.Sprintf("%s/%s/pages", reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -132,6 +163,7 @@ func (s *ReportsService) ListPages(ctx context.Context, reportID string) ([]type
 
 // ListPagesInGroup returns the pages for a report in a workspace.
 func (s *ReportsService) ListPagesInGroup(ctx context.Context, groupID, reportID string) ([]types.Page, error) {
+	ctx = withOperation(ctx, "Reports.ListPagesInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/pages", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -147,6 +179,7 @@ func (s *ReportsService) ListPagesInGroup(ctx context.Context, groupID, reportID
 
 // Get returns the specified report from My workspace.
 func (s *ReportsService) Get(ctx context.Context, reportID string) (*types.Report, error) {
+	ctx = withOperation(ctx, "Reports.Get")
 	u := fmt.Sprintf("%s/%s", reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -159,6 +192,7 @@ func (s *ReportsService) Get(ctx context.Context, reportID string) (*types.Repor
 
 // GetInGroup returns the specified report from the specified workspace.
 func (s *ReportsService) GetInGroup(ctx context.Context, groupID, reportID string) (*types.Report, error) {
+	ctx = withOperation(ctx, "Reports.GetInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -171,6 +205,7 @@ func (s *ReportsService) GetInGroup(ctx context.Context, groupID, reportID strin
 
 // List a list of reports from My workspace.
 func (s *ReportsService) List(ctx context.Context) ([]types.Report, error) {
+	ctx = withOperation(ctx, "Reports.List")
 	u := reportsBasePath
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -186,6 +221,7 @@ func (s *ReportsService) List(ctx context.Context) ([]types.Report, error) {
 
 // ListInGroup a list of reports from the specified workspace.
 func (s *ReportsService) ListInGroup(ctx context.Context, groupID string) ([]types.Report, error) {
+	ctx = withOperation(ctx, "Reports.ListInGroup")
 	u := fmt.Sprintf("%s/%s/%s", groupsBasePath, url.PathEscape(groupID), reportsBasePath)
 	_, resp, err := s.client.doRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -202,8 +238,9 @@ func (s *ReportsService) ListInGroup(ctx context.Context, groupID string) ([]typ
 // Rebind rebinds the specified report from My workspace to the specified dataset.
 // POST /reports/{reportId}/Rebind
 func (s *ReportsService) Rebind(ctx context.Context, reportID string, req types.RebindReportRequest) error {
+	ctx = withOperation(ctx, "Reports.Rebind")
 	u := fmt.Sprintf("%s/%s/Rebind", reportsBasePath, url.PathEscape(reportID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return err
 	}
@@ -214,8 +251,9 @@ func (s *ReportsService) Rebind(ctx context.Context, reportID string, req types.
 
 // RebindInGroup rebinds the specified report from the specified workspace to the specified dataset.
 func (s *ReportsService) RebindInGroup(ctx context.Context, groupID, reportID string, req types.RebindReportRequest) error {
+	ctx = withOperation(ctx, "Reports.RebindInGroup")
 	u := fmt.Sprintf("%s/%s/%s/%s/Rebind", groupsBasePath, url.PathEscape(groupID), reportsBasePath, url.PathEscape(reportID))
-	_, resp, err := s.client.postJSON(ctx, u, req)
+	_, resp, err := s.client.postJSON(withIdempotent(ctx), u, req)
 	if err != nil {
 		return err
 	}
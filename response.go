@@ -0,0 +1,91 @@
+package powerbi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Response wraps the http.Response of a Power BI API call and exposes the
+// metadata that service methods otherwise discard. The body has already been
+// consumed by the service method.
+type Response struct {
+	*http.Response
+
+	// RequestID is the Power BI request ID to quote to Microsoft support.
+	RequestID string
+
+	// RetryAfter is the delay requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+
+	// Location is the Location header, which points at the status of
+	// asynchronous operations answered with 202 Accepted.
+	Location string
+
+	// NextLink is the link to the next page of results, taken from the
+	// @odata.nextLink or continuationUri field of the body, if any.
+	NextLink string
+
+	// Attempts is the number of times the request was sent, including retries.
+	Attempts int
+}
+
+// Throttled reports whether Power BI answered 429 Too Many Requests.
+func (r *Response) Throttled() bool {
+	return r.StatusCode == http.StatusTooManyRequests
+}
+
+type responseKey struct{}
+
+// WithResponse returns a copy of ctx that makes the service method called
+// with it store its Response in *resp, whether the call succeeds or fails
+// with an HTTP error:
+//
+//	var resp *powerbi.Response
+//	report, err := pbi.Reports.Get(powerbi.WithResponse(ctx, &resp), reportID)
+//	fmt.Println(resp.StatusCode, resp.RequestID)
+//
+// Iterators store the Response of the last page they fetched.
+func WithResponse(ctx context.Context, resp **Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, resp)
+}
+
+// captureResponse stores resp in the Response requested by WithResponse, if
+// any. The next-page link is read from the body, which is buffered for that.
+func captureResponse(ctx context.Context, resp *http.Response) {
+	target, _ := ctx.Value(responseKey{}).(**Response)
+	if target == nil {
+		return
+	}
+
+	r := &Response{
+		Response:  resp,
+		RequestID: RequestID(resp.Header),
+		Location:  resp.Header.Get("Location"),
+		Attempts:  Attempts(ctx),
+	}
+	r.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), mediaType) {
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if err == nil {
+			var page struct {
+				NextLink        string `json:"@odata.nextLink"`
+				ContinuationURI string `json:"continuationUri"`
+			}
+			if json.Unmarshal(data, &page) == nil {
+				r.NextLink = page.NextLink
+				if r.NextLink == "" {
+					r.NextLink = page.ContinuationURI
+				}
+			}
+		}
+	}
+	*target = r
+}
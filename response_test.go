@@ -0,0 +1,87 @@
+package powerbi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestWithResponse(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/reports/r1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("RequestId", "req1")
+		w.Header().Set("X-Custom", "yes")
+		io.WriteString(w, `{"id":"r1","name":"Sales"}`)
+	})
+
+	var resp *Response
+	report, err := c.Reports.Get(WithResponse(context.Background(), &resp), "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The body is buffered for the next-page link and still decoded.
+	if report.Name != "Sales" {
+		t.Errorf("Get() = %+v, want the Sales report", report)
+	}
+	if resp == nil {
+		t.Fatal("WithResponse() did not store the response")
+	}
+	if resp.StatusCode != http.StatusOK || resp.RequestID != "req1" || resp.Header.Get("X-Custom") != "yes" || resp.Attempts != 1 {
+		t.Errorf("Response = %d, request ID %q, X-Custom %q, %d attempts, want 200, req1, yes, 1",
+			resp.StatusCode, resp.RequestID, resp.Header.Get("X-Custom"), resp.Attempts)
+	}
+}
+
+func TestWithResponseError(t *testing.T) {
+	c, mux := setup(t)
+	var attempts atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/reports/r1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-request-id", "req2")
+		if attempts.Add(1) < 4 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"error":{"code":"TooManyRequests"}}`)
+	})
+
+	var resp *Response
+	_, err := c.Reports.Get(WithResponse(context.Background(), &resp), "r1")
+	if !types.IsThrottled(err) || !types.HasErrorCode(err, "TooManyRequests") {
+		t.Fatalf("Get() error = %v, want a throttling error with its code", err)
+	}
+	if resp == nil {
+		t.Fatal("WithResponse() did not store the error response")
+	}
+	if !resp.Throttled() || resp.RequestID != "req2" || resp.RetryAfter != 2*time.Minute || resp.Attempts != 4 {
+		t.Errorf("Response = %d, request ID %q, Retry-After %v, %d attempts, want 429, req2, 2m, 4",
+			resp.StatusCode, resp.RequestID, resp.RetryAfter, resp.Attempts)
+	}
+}
+
+func TestWithResponseNextLink(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/admin/groups/g1/unused", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "https://example.com/status")
+		io.WriteString(w, `{"unusedArtifactEntities":[],"continuationUri":"https://example.com/next"}`)
+	})
+
+	var resp *Response
+	_, err := c.Admin.Groups().GetUnusedArtifactsAsAdmin(WithResponse(context.Background(), &resp), "g1", types.UnusedArtifactsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NextLink != "https://example.com/next" || resp.Location != "https://example.com/status" {
+		t.Errorf("Response next link %q, location %q", resp.NextLink, resp.Location)
+	}
+}
@@ -0,0 +1,182 @@
+package powerbi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries throttled and transient failures.
+//
+// Requests are retried when Power BI answers 408, 429, 500, 502, 503 or 504, or
+// when the connection fails before a response is received. Throttled requests
+// (429) are rejected before they are processed, so they are retried for every
+// method. Other failures are only retried for idempotent requests: GET, PUT,
+// DELETE and the POST operations that have no side effects, such as
+// GenerateToken and ExecuteQueries.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// MinBackoff is the base delay of the exponential backoff.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter caps how long the client honors a Retry-After header.
+	// When Power BI asks to wait longer, the error is returned to the caller
+	// instead. A zero value honors any Retry-After header.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:   4,
+		MinBackoff:    500 * time.Millisecond,
+		MaxBackoff:    30 * time.Second,
+		MaxRetryAfter: 2 * time.Minute,
+	}
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks the request made with ctx as safe to retry regardless
+// of its HTTP method.
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	v, _ := req.Context().Value(idempotentKey{}).(bool)
+	return v
+}
+
+// wrap returns a Doer that sends requests through next, retrying them
+// according to the policy. Request bodies are replayed through req.GetBody,
+// which http.NewRequest sets for the in-memory bodies built by postJSON,
+// putJSON and patchJSON.
+func (p *RetryPolicy) wrap(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		for attempt := 1; ; attempt++ {
+			if attempt > 1 {
+				r, err := rewindRequest(req)
+				if err != nil {
+					return nil, err
+				}
+				req = r
+			}
+
+			resp, err := next.Do(req)
+			wait, ok := p.backoff(req, resp, err, attempt)
+			if !ok {
+				return resp, err
+			}
+			if resp != nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+
+			t := time.NewTimer(wait)
+			select {
+			case <-req.Context().Done():
+				t.Stop()
+				return nil, req.Context().Err()
+			case <-t.C:
+			}
+		}
+	})
+}
+
+// backoff reports whether the outcome of an attempt should be retried and how
+// long to wait before doing so.
+func (p *RetryPolicy) backoff(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return 0, false
+	}
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || !isIdempotent(req) {
+			return 0, false
+		}
+		return p.jitter(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(req) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		if p.MaxRetryAfter > 0 && d > p.MaxRetryAfter {
+			return 0, false
+		}
+		return d, true
+	}
+	return p.jitter(attempt), true
+}
+
+// jitter returns an exponential backoff delay with full jitter for attempt.
+func (p *RetryPolicy) jitter(attempt int) time.Duration {
+	d := p.MaxBackoff
+	if shift := attempt - 1; shift < 32 {
+		if b := p.MinBackoff << shift; b > 0 && (d <= 0 || b < d) {
+			d = b
+		}
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	return max(t.Sub(now), 0), true
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
@@ -0,0 +1,168 @@
+package powerbi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+func TestRetryThrottled(t *testing.T) {
+	c, mux := setup(t)
+	var attempts atomic.Int32
+	mux.HandleFunc("POST /v1.0/myorg/groups", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"Sales"}` {
+			t.Errorf("attempt %d body = %q", attempts.Load()+1, body)
+		}
+		if attempts.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"g1","name":"Sales"}`))
+	})
+
+	// Throttled POSTs are retried even though they are not idempotent, with
+	// their body replayed.
+	_, resp, err := c.postJSON(context.Background(), "groups", map[string]string{"name": "Sales"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("attempts = %d, want 3", n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	c, mux := setup(t)
+	var attempts atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/groups", func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("RequestId", "r1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, _, err := c.doRequest(context.Background(), http.MethodGet, "groups", nil)
+	var errHTTP *types.ErrHTTP
+	if !errors.As(err, &errHTTP) || errHTTP.Code != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want a 503 *types.ErrHTTP", err)
+	}
+	if n := attempts.Load(); n != 4 {
+		t.Errorf("attempts = %d, want MaxAttempts = 4", n)
+	}
+}
+
+func TestRetryOnlyIdempotent(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		ctx    context.Context
+		want   int32
+	}{
+		{"POST", http.MethodPost, context.Background(), 1},
+		{"idempotent POST", http.MethodPost, withIdempotent(context.Background()), 4},
+		{"DELETE", http.MethodDelete, context.Background(), 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux := setup(t)
+			var attempts atomic.Int32
+			mux.HandleFunc("/v1.0/myorg/groups", func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			_, _, err := c.doRequest(tt.ctx, tt.method, "groups", strings.NewReader("{}"))
+			if err == nil {
+				t.Fatal("want an error")
+			}
+			if n := attempts.Load(); n != tt.want {
+				t.Errorf("attempts = %d, want %d", n, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	c, mux := setup(t)
+	c.RetryPolicy.MaxRetryAfter = time.Minute
+	var attempts atomic.Int32
+	mux.HandleFunc("GET /v1.0/myorg/groups", func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, _, err := c.doRequest(context.Background(), http.MethodGet, "groups", nil)
+	var errHTTP *types.ErrHTTP
+	if !errors.As(err, &errHTTP) {
+		t.Fatalf("err = %v, want *types.ErrHTTP", err)
+	}
+	if errHTTP.RetryAfter != time.Hour {
+		t.Errorf("RetryAfter = %v, want 1h", errHTTP.RetryAfter)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("attempts = %d, want 1", n)
+	}
+}
+
+func TestRetryCanceledWhileWaiting(t *testing.T) {
+	c, mux := setup(t)
+	mux.HandleFunc("GET /v1.0/myorg/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := c.doRequest(ctx, http.MethodGet, "groups", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, limit := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for range 100 {
+			if d := p.jitter(attempt); d < 0 || d >= limit {
+				t.Fatalf("jitter(%d) = %v, want in [0, %v)", attempt, d, limit)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Wed, 31 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 31 Jan 2024 11:00:00 GMT", 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
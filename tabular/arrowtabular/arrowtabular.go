@@ -0,0 +1,353 @@
+// Package arrowtabular writes the rows of ExecuteQueries results as Apache
+// Arrow IPC streams and Parquet files, with column types inferred from the
+// values of the rows.
+//
+// It implements tabular.Writer, so rows can be copied from a result table or
+// an iterator with tabular.Copy:
+//
+//	w := arrowtabular.NewParquetWriter(f, arrowtabular.Options{})
+//	err := tabular.WriteTable(w, resp.Results[0].Tables[0])
+package arrowtabular
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/stpabhi/powerbi-go/tabular"
+)
+
+// defaultBatchSize is the number of rows per record batch when the caller
+// sets none.
+const defaultBatchSize = 10_000
+
+// Options configures the writers.
+type Options struct {
+	tabular.Options
+
+	// Schema sets the type of each column, by name, instead of inferring it.
+	// Supported types are Boolean, Int32, Int64, Float32, Float64, String,
+	// Date32 and Timestamp. Fields must be nullable to hold null values.
+	Schema *arrow.Schema
+
+	// Types sets the type of some columns, by name, and leaves the others
+	// inferred. It is ignored if Schema is set. The supported types are
+	// those of Schema.
+	Types map[string]arrow.DataType
+
+	// BatchSize is the number of rows per record batch, and per Parquet row
+	// group. The schema is inferred from the first batch, so values of
+	// later batches that do not convert to the inferred types, such as text
+	// in a column of date times, fail the write; set Schema or Types for
+	// such columns. It defaults to 10,000.
+	BatchSize int
+
+	// Allocator allocates the record batches. It defaults to
+	// memory.DefaultAllocator.
+	Allocator memory.Allocator
+}
+
+// Writer writes rows as record batches of an Arrow or Parquet file. It
+// implements tabular.Writer.
+type Writer struct {
+	opts    Options
+	columns tabular.ColumnSet
+	started bool
+	pending [][]any
+	schema  *arrow.Schema
+
+	open  func(schema *arrow.Schema) error
+	write func(rec arrow.RecordBatch) error
+	close func() error
+}
+
+// nopCloser keeps the Arrow and Parquet writers from closing the io.Writer
+// they write to.
+type nopCloser struct {
+	io.Writer
+}
+
+// NewIPCWriter returns a Writer of an Arrow IPC stream to w.
+func NewIPCWriter(w io.Writer, opts Options) *Writer {
+	wr := newWriter(opts)
+	var iw *ipc.Writer
+	wr.open = func(schema *arrow.Schema) error {
+		iw = ipc.NewWriter(nopCloser{w}, ipc.WithSchema(schema), ipc.WithAllocator(wr.opts.Allocator))
+		return nil
+	}
+	wr.write = func(rec arrow.RecordBatch) error { return iw.Write(rec) }
+	wr.close = func() error { return iw.Close() }
+	return wr
+}
+
+// NewParquetWriter returns a Writer of a Parquet file to w, compressed with
+// Snappy.
+func NewParquetWriter(w io.Writer, opts Options) *Writer {
+	wr := newWriter(opts)
+	var pw *pqarrow.FileWriter
+	wr.open = func(schema *arrow.Schema) error {
+		props := parquet.NewWriterProperties(
+			parquet.WithCompression(compress.Codecs.Snappy),
+			parquet.WithAllocator(wr.opts.Allocator),
+		)
+		var err error
+		pw, err = pqarrow.NewFileWriter(schema, nopCloser{w}, props, pqarrow.DefaultWriterProps())
+		return err
+	}
+	wr.write = func(rec arrow.RecordBatch) error { return pw.Write(rec) }
+	wr.close = func() error { return pw.Close() }
+	return wr
+}
+
+func newWriter(opts Options) *Writer {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.Allocator == nil {
+		opts.Allocator = memory.DefaultAllocator
+	}
+	return &Writer{opts: opts}
+}
+
+// WriteRow implements tabular.Writer.
+func (w *Writer) WriteRow(row map[string]any) error {
+	if !w.started {
+		w.columns.Init(w.opts.Options, row)
+		w.started = true
+	}
+	values, err := w.columns.Values(row)
+	if err != nil {
+		return err
+	}
+	w.pending = append(w.pending, values)
+	if len(w.pending) >= w.opts.BatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close implements tabular.Writer. It writes the buffered rows and the footer
+// of the file.
+func (w *Writer) Close() error {
+	if !w.started {
+		w.columns.Init(w.opts.Options, nil)
+		w.started = true
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	return w.close()
+}
+
+// Schema returns the schema of the file, once it has been inferred from the
+// first batch of rows.
+func (w *Writer) Schema() *arrow.Schema {
+	return w.schema
+}
+
+func (w *Writer) flush() error {
+	if w.schema == nil {
+		schema := w.opts.Schema
+		if schema == nil {
+			schema = inferSchema(w.columns.Names(), w.pending, w.opts.Types)
+		}
+		if err := checkSchema(schema, w.columns.Names()); err != nil {
+			return err
+		}
+		if err := w.open(schema); err != nil {
+			return err
+		}
+		w.schema = schema
+	}
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	b := array.NewRecordBuilder(w.opts.Allocator, w.schema)
+	defer b.Release()
+	for _, values := range w.pending {
+		for i, v := range values {
+			if err := appendValue(b.Field(i), v); err != nil {
+				return fmt.Errorf("arrowtabular: column %q: %w", w.schema.Field(i).Name, err)
+			}
+		}
+	}
+	w.pending = w.pending[:0]
+
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+	return w.write(rec)
+}
+
+// checkSchema checks that schema has a field of a supported type for each
+// column, in order.
+func checkSchema(schema *arrow.Schema, columns []string) error {
+	if schema.NumFields() != len(columns) {
+		return fmt.Errorf("arrowtabular: schema has %d fields for %d columns", schema.NumFields(), len(columns))
+	}
+	for i, f := range schema.Fields() {
+		if f.Name != columns[i] {
+			return fmt.Errorf("arrowtabular: schema field %d is %q, not column %q", i, f.Name, columns[i])
+		}
+		switch f.Type.ID() {
+		case arrow.BOOL, arrow.INT32, arrow.INT64, arrow.FLOAT32, arrow.FLOAT64, arrow.STRING, arrow.DATE32, arrow.TIMESTAMP:
+		default:
+			return fmt.Errorf("arrowtabular: column %q has unsupported type %s", f.Name, f.Type)
+		}
+	}
+	return nil
+}
+
+// timestampType is the type of inferred date time columns.
+var timestampType = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+
+// InferSchema returns the schema of the columns of rows, where rows hold the
+// values of columns in order. A column is Boolean if all its values are
+// booleans, Float64 if they are numbers, Timestamp if they are date times,
+// such as "2024-01-31T00:00:00", and String otherwise. Numbers are Float64
+// even if they are all integral, as a later row may not be; set the Types
+// of the options to write Int64 columns. Null values are ignored, and all
+// fields are nullable.
+func InferSchema(columns []string, rows [][]any) *arrow.Schema {
+	return inferSchema(columns, rows, nil)
+}
+
+// inferSchema is InferSchema with the types of some columns set.
+func inferSchema(columns []string, rows [][]any, types map[string]arrow.DataType) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, name := range columns {
+		typ, ok := types[name]
+		if !ok {
+			typ = inferType(rows, i)
+		}
+		fields[i] = arrow.Field{Name: name, Type: typ, Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+func inferType(rows [][]any, col int) arrow.DataType {
+	bools, nums, times, strs := 0, 0, 0, 0
+	for _, row := range rows {
+		switch v := row[col].(type) {
+		case nil:
+			continue
+		case bool:
+			bools++
+		case float64:
+			nums++
+		case string:
+			if _, err := parseTime(v); err == nil {
+				times++
+			} else {
+				strs++
+			}
+		default:
+			strs++
+		}
+	}
+	switch {
+	case strs > 0 || bools+nums+times == 0:
+		return arrow.BinaryTypes.String
+	case bools > 0 && nums+times == 0:
+		return arrow.FixedWidthTypes.Boolean
+	case times > 0 && bools+nums == 0:
+		return timestampType
+	case nums > 0 && bools+times == 0:
+		return arrow.PrimitiveTypes.Float64
+	}
+	return arrow.BinaryTypes.String
+}
+
+// timeLayouts are the layouts of the date times of ExecuteQueries results.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %q to a date time", s)
+}
+
+// appendValue appends v, a value decoded from JSON, to b.
+func appendValue(b array.Builder, v any) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+	switch b := b.(type) {
+	case *array.StringBuilder:
+		b.Append(formatString(v))
+		return nil
+	case *array.BooleanBuilder:
+		if x, ok := v.(bool); ok {
+			b.Append(x)
+			return nil
+		}
+	case *array.Int64Builder:
+		if x, ok := v.(float64); ok && x == math.Trunc(x) && x >= math.MinInt64 && x < math.MaxInt64 {
+			b.Append(int64(x))
+			return nil
+		}
+	case *array.Int32Builder:
+		if x, ok := v.(float64); ok && x == math.Trunc(x) && x >= math.MinInt32 && x <= math.MaxInt32 {
+			b.Append(int32(x))
+			return nil
+		}
+	case *array.Float64Builder:
+		if x, ok := v.(float64); ok {
+			b.Append(x)
+			return nil
+		}
+	case *array.Float32Builder:
+		if x, ok := v.(float64); ok {
+			b.Append(float32(x))
+			return nil
+		}
+	case *array.TimestampBuilder:
+		if s, ok := v.(string); ok {
+			t, err := parseTime(s)
+			if err != nil {
+				return err
+			}
+			b.AppendTime(t)
+			return nil
+		}
+	case *array.Date32Builder:
+		if s, ok := v.(string); ok {
+			t, err := parseTime(s)
+			if err != nil {
+				return err
+			}
+			b.Append(arrow.Date32FromTime(t))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot convert %v (%T) to %s; set the Schema or Types of the options to change the inferred type", v, v, b.Type())
+}
+
+func formatString(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,71 @@
+package arrowtabular
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+
+	"github.com/stpabhi/powerbi-go/tabular"
+)
+
+func TestInferSchema(t *testing.T) {
+	rows := [][]any{
+		{true, 1.0, 1.5, "2024-01-31T00:00:00", "a", nil, 1.0},
+		{false, 2.0, nil, "2024-02-01T12:30:00", "2024-01-31T00:00:00", nil, true},
+	}
+	columns := []string{"bool", "int", "float", "time", "string", "null", "mixed"}
+	want := []arrow.DataType{
+		arrow.FixedWidthTypes.Boolean,
+		arrow.PrimitiveTypes.Float64,
+		arrow.PrimitiveTypes.Float64,
+		timestampType,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.String,
+	}
+	schema := InferSchema(columns, rows)
+	for i, f := range schema.Fields() {
+		if !arrow.TypeEqual(f.Type, want[i]) {
+			t.Errorf("column %q: type %s, want %s", f.Name, f.Type, want[i])
+		}
+	}
+}
+
+func TestWriterNumbersAfterFirstBatch(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewIPCWriter(&buf, Options{
+		Types:     map[string]arrow.DataType{"[ID]": arrow.PrimitiveTypes.Int64},
+		BatchSize: 1,
+	})
+	err := tabular.Copy(w, func(yield func(map[string]any, error) bool) {
+		_ = yield(map[string]any{"[ID]": 1.0, "[Amount]": 2.0}, nil) &&
+			yield(map[string]any{"[ID]": 2.0, "[Amount]": 2.5}, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	if got := r.Schema().Field(0).Type; !arrow.TypeEqual(got, arrow.PrimitiveTypes.Float64) {
+		t.Errorf("[Amount] type %s, want float64", got)
+	}
+	if got := r.Schema().Field(1).Type; !arrow.TypeEqual(got, arrow.PrimitiveTypes.Int64) {
+		t.Errorf("[ID] type %s, want int64", got)
+	}
+	rows := 0
+	for r.Next() {
+		rows += int(r.RecordBatch().NumRows())
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 2 {
+		t.Errorf("%d rows, want 2", rows)
+	}
+}
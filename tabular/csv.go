@@ -0,0 +1,69 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CSVWriter writes rows as CSV, with a header row of column names. Null
+// values are written as empty fields.
+type CSVWriter struct {
+	w       *csv.Writer
+	opts    Options
+	columns ColumnSet
+	record  []string
+}
+
+// NewCSVWriter returns a CSVWriter writing to w.
+func NewCSVWriter(w io.Writer, opts Options) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), opts: opts}
+}
+
+// WriteRow implements Writer.
+func (w *CSVWriter) WriteRow(row map[string]any) error {
+	if w.record == nil {
+		w.columns.Init(w.opts, row)
+		w.record = make([]string, len(w.columns.Names()))
+		if err := w.w.Write(w.columns.Names()); err != nil {
+			return err
+		}
+	}
+	values, err := w.columns.Values(row)
+	if err != nil {
+		return err
+	}
+	for i, v := range values {
+		w.record[i] = formatCSV(v)
+	}
+	return w.w.Write(w.record)
+}
+
+// Close implements Writer. It writes the header of the Columns of the
+// options if no row was written.
+func (w *CSVWriter) Close() error {
+	if w.record == nil && len(w.opts.Columns) > 0 {
+		w.columns.Init(w.opts, nil)
+		w.record = []string{}
+		if err := w.w.Write(w.columns.Names()); err != nil {
+			return err
+		}
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func formatCSV(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,62 @@
+package tabular
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// NDJSONWriter writes rows as newline-delimited JSON, one object per row with
+// its columns in order. Null and missing values are written as null.
+type NDJSONWriter struct {
+	w       *bufio.Writer
+	opts    Options
+	columns ColumnSet
+	started bool
+	buf     bytes.Buffer
+}
+
+// NewNDJSONWriter returns an NDJSONWriter writing to w.
+func NewNDJSONWriter(w io.Writer, opts Options) *NDJSONWriter {
+	return &NDJSONWriter{w: bufio.NewWriter(w), opts: opts}
+}
+
+// WriteRow implements Writer.
+func (w *NDJSONWriter) WriteRow(row map[string]any) error {
+	if !w.started {
+		w.columns.Init(w.opts, row)
+		w.started = true
+	}
+	values, err := w.columns.Values(row)
+	if err != nil {
+		return err
+	}
+
+	w.buf.Reset()
+	w.buf.WriteByte('{')
+	for i, name := range w.columns.Names() {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		w.buf.Write(key)
+		w.buf.WriteByte(':')
+		w.buf.Write(value)
+	}
+	w.buf.WriteString("}\n")
+	_, err = w.w.Write(w.buf.Bytes())
+	return err
+}
+
+// Close implements Writer.
+func (w *NDJSONWriter) Close() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,143 @@
+// Package tabular writes the rows of ExecuteQueries results to files, as CSV
+// or newline-delimited JSON. The arrowtabular subpackage writes Apache Arrow
+// and Parquet files.
+//
+// Rows are the JSON objects of a result table, keyed by column names such as
+// "Sales[Amount]". They can come from a table, through Rows, or be streamed
+// from an iterator such as QueryRows:
+//
+//	q.IncludeNulls = true
+//	w := tabular.NewCSVWriter(f, tabular.Options{})
+//	err := tabular.Copy(w, pbi.Datasets.Group().QueryRows(ctx, groupID, datasetID, q))
+//
+// The writers write the columns of the first row unless Options.Columns is
+// set. Results omit null values unless the query sets IncludeNulls, so a
+// later row could have a column the first row lacks, which fails with
+// ErrUnknownColumn. Include nulls in the results, or set Options.Columns,
+// for instance to the Columns of a table.
+package tabular
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+// Writer writes rows to a file format.
+type Writer interface {
+	// WriteRow writes a row. Writers may buffer rows until Close.
+	WriteRow(row map[string]any) error
+
+	// Close flushes the buffered rows. It does not close the underlying
+	// io.Writer.
+	Close() error
+}
+
+// Options configures the writers.
+type Options struct {
+	// Columns lists the columns to write, in order. It defaults to the
+	// columns of the first row, sorted by name, which must then include the
+	// columns of every row, as they do when the query sets IncludeNulls.
+	Columns []string
+}
+
+// ErrUnknownColumn is returned when a row has a column that is not in the
+// columns of the writer, such as a column that was null in the first row of
+// a result without nulls.
+var ErrUnknownColumn = errors.New("tabular: column is not in the written columns")
+
+// Rows returns an iterator over the rows of a result table.
+func Rows(table types.DatasetExecuteQueriesTableResult) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		for i, row := range table.Rows {
+			m, ok := row.(map[string]any)
+			if !ok {
+				yield(nil, fmt.Errorf("tabular: row %d is a %T, not an object", i, row))
+				return
+			}
+			if !yield(m, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Columns returns the names of the columns of the rows of a result table,
+// sorted.
+func Columns(table types.DatasetExecuteQueriesTableResult) []string {
+	seen := make(map[string]bool)
+	for _, row := range table.Rows {
+		if m, ok := row.(map[string]any); ok {
+			for k := range m {
+				seen[k] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// Copy writes the rows of seq to w and closes w. It stops at the first error
+// of seq or w.
+func Copy(w Writer, seq iter.Seq2[map[string]any, error]) error {
+	for row, err := range seq {
+		if err == nil {
+			err = w.WriteRow(row)
+		}
+		if err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// WriteTable writes the rows of a result table to w and closes w.
+func WriteTable(w Writer, table types.DatasetExecuteQueriesTableResult) error {
+	return Copy(w, Rows(table))
+}
+
+// ColumnSet tracks the columns of a writer. It is exported for the writers
+// of the subpackages.
+type ColumnSet struct {
+	names []string
+	index map[string]int
+}
+
+// Init sets the columns to opts.Columns or, if empty, to the sorted columns
+// of row. It does nothing once the columns are set.
+func (c *ColumnSet) Init(opts Options, row map[string]any) {
+	if c.index != nil {
+		return
+	}
+	c.names = slices.Clone(opts.Columns)
+	if len(c.names) == 0 {
+		c.names = slices.Sorted(maps.Keys(row))
+	}
+	c.index = make(map[string]int, len(c.names))
+	for i, name := range c.names {
+		c.index[name] = i
+	}
+}
+
+// Names returns the columns in order.
+func (c *ColumnSet) Names() []string {
+	return c.names
+}
+
+// Values returns the values of row in column order, nil for missing columns.
+// It fails with ErrUnknownColumn if row has a column not in the set.
+func (c *ColumnSet) Values(row map[string]any) ([]any, error) {
+	values := make([]any, len(c.names))
+	for k, v := range row {
+		i, ok := c.index[k]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q; set the Columns of the options or include nulls in the query results", ErrUnknownColumn, k)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
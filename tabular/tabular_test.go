@@ -0,0 +1,145 @@
+package tabular
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stpabhi/powerbi-go/types"
+)
+
+var testTable = types.DatasetExecuteQueriesTableResult{Rows: []any{
+	map[string]any{"Sales[ID]": 1.0, "Sales[Note]": `say "hi", twice`, "[Total]": 12.5, "Sales[Paid]": true},
+	map[string]any{"Sales[ID]": 2.0, "Sales[Note]": nil, "[Total]": 1e21, "Sales[Paid]": false},
+}}
+
+func TestCSVWriter(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTable(NewCSVWriter(&b, Options{}), testTable); err != nil {
+		t.Fatal(err)
+	}
+	const want = "Sales[ID],Sales[Note],Sales[Paid],[Total]\n" +
+		"1,\"say \"\"hi\"\", twice\",true,12.5\n" +
+		"2,,false,1000000000000000000000\n"
+	if got := b.String(); got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCSVWriterColumns(t *testing.T) {
+	var b strings.Builder
+	w := NewCSVWriter(&b, Options{Columns: []string{"[Total]", "Sales[ID]", "Sales[Missing]"}})
+	err := Copy(w, func(yield func(map[string]any, error) bool) {
+		yield(map[string]any{"Sales[ID]": 1.0, "[Total]": 2.0}, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "[Total],Sales[ID],Sales[Missing]\n2,1,\n"; got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
+
+func TestCSVWriterNoRows(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTable(NewCSVWriter(&b, Options{Columns: []string{"A", "B"}}), types.DatasetExecuteQueriesTableResult{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "A,B\n"; got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+
+	b.Reset()
+	if err := WriteTable(NewCSVWriter(&b, Options{}), types.DatasetExecuteQueriesTableResult{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != "" {
+		t.Errorf("CSV = %q, want empty", got)
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTable(NewNDJSONWriter(&b, Options{}), testTable); err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"Sales[ID]":1,"Sales[Note]":"say \"hi\", twice","Sales[Paid]":true,"[Total]":12.5}` + "\n" +
+		`{"Sales[ID]":2,"Sales[Note]":null,"Sales[Paid]":false,"[Total]":1e+21}` + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("NDJSON =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNDJSONWriterColumns(t *testing.T) {
+	var b strings.Builder
+	w := NewNDJSONWriter(&b, Options{Columns: []string{"B", "A"}})
+	err := Copy(w, func(yield func(map[string]any, error) bool) {
+		_ = yield(map[string]any{"A": "x"}, nil) && yield(map[string]any{"B": 1.0}, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "{\"B\":null,\"A\":\"x\"}\n{\"B\":1,\"A\":null}\n"; got != want {
+		t.Errorf("NDJSON = %q, want %q", got, want)
+	}
+}
+
+func TestWritersUnknownColumn(t *testing.T) {
+	// Without nulls, the second row has a column the first row lacks.
+	table := types.DatasetExecuteQueriesTableResult{Rows: []any{
+		map[string]any{"A": 1.0},
+		map[string]any{"A": 2.0, "B": "x"},
+	}}
+	for name, w := range map[string]Writer{
+		"csv":    NewCSVWriter(&strings.Builder{}, Options{}),
+		"ndjson": NewNDJSONWriter(&strings.Builder{}, Options{}),
+	} {
+		if err := WriteTable(w, table); !errors.Is(err, ErrUnknownColumn) {
+			t.Errorf("%s: err = %v, want ErrUnknownColumn", name, err)
+		}
+	}
+
+	var b strings.Builder
+	if err := WriteTable(NewCSVWriter(&b, Options{Columns: Columns(table)}), table); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "A,B\n1,\n2,x\n"; got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	if got, want := Columns(testTable), []string{"Sales[ID]", "Sales[Note]", "Sales[Paid]", "[Total]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Columns() = %q, want %q", got, want)
+	}
+}
+
+func TestRowsNotObject(t *testing.T) {
+	table := types.DatasetExecuteQueriesTableResult{Rows: []any{map[string]any{"A": 1.0}, []any{1.0}}}
+	n := 0
+	var err error
+	for _, err = range Rows(table) {
+		if err != nil {
+			break
+		}
+		n++
+	}
+	if n != 1 || err == nil {
+		t.Errorf("Rows() yielded %d rows and error %v, want 1 row and an error", n, err)
+	}
+}
+
+func TestCopyStopsAtError(t *testing.T) {
+	var b strings.Builder
+	errSeq := errors.New("sequence failed")
+	err := Copy(NewCSVWriter(&b, Options{}), func(yield func(map[string]any, error) bool) {
+		_ = yield(map[string]any{"A": 1.0}, nil) && yield(nil, errSeq) && yield(map[string]any{"A": 2.0}, nil)
+	})
+	if !errors.Is(err, errSeq) {
+		t.Errorf("Copy() = %v, want %v", err, errSeq)
+	}
+	if got, want := b.String(), "A\n1\n"; got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package powerbi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultScope is the OAuth 2.0 scope of the Power BI REST APIs in the public
+// cloud. See Cloud for the scopes of the other clouds.
+const DefaultScope = "https://analysis.windows.net/powerbi/api/.default"
+
+// Token is an OAuth 2.0 access token for the Power BI REST APIs.
+type Token struct {
+	AccessToken string
+
+	// ExpiresOn is when the token expires. A zero value means the token
+	// never expires.
+	ExpiresOn time.Time
+}
+
+// TokenProvider supplies access tokens to TokenTransport.
+type TokenProvider interface {
+	GetToken(ctx context.Context) (Token, error)
+}
+
+// TokenProviderFunc is an adapter to allow the use of ordinary functions as
+// token providers.
+type TokenProviderFunc func(ctx context.Context) (Token, error)
+
+// GetToken calls f(ctx).
+func (f TokenProviderFunc) GetToken(ctx context.Context) (Token, error) {
+	return f(ctx)
+}
+
+// defaultRefreshBefore is how long before expiry a cached token is refreshed.
+const defaultRefreshBefore = 5 * time.Minute
+
+// CachedTokenProvider is a TokenProvider that caches the tokens of an
+// underlying provider. Tokens are refreshed in the background once they are
+// within RefreshBefore of their expiry, and concurrent callers share a single
+// call to the underlying provider.
+type CachedTokenProvider struct {
+	// Provider is the underlying token provider.
+	Provider TokenProvider
+
+	// RefreshBefore is how long before expiry a token is refreshed.
+	// It defaults to 5 minutes.
+	RefreshBefore time.Duration
+
+	mu       sync.Mutex
+	token    Token
+	inflight *tokenCall
+}
+
+type tokenCall struct {
+	done  chan struct{}
+	token Token
+	err   error
+}
+
+var _ TokenProvider = &CachedTokenProvider{}
+
+// NewCachedTokenProvider returns a CachedTokenProvider for p.
+func NewCachedTokenProvider(p TokenProvider) *CachedTokenProvider {
+	return &CachedTokenProvider{Provider: p}
+}
+
+// GetToken returns the cached token, fetching a new one from the underlying
+// provider when the cached token is missing or expired.
+func (c *CachedTokenProvider) GetToken(ctx context.Context) (Token, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	if c.token.AccessToken != "" && (c.token.ExpiresOn.IsZero() || now.Before(c.token.ExpiresOn)) {
+		t := c.token
+		if !t.ExpiresOn.IsZero() && now.After(t.ExpiresOn.Add(-c.refreshBefore())) {
+			c.refreshLocked(ctx)
+		}
+		c.mu.Unlock()
+		return t, nil
+	}
+	call := c.refreshLocked(ctx)
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	}
+}
+
+// Invalidate discards the cached token if it is still token, so that the next
+// call to GetToken fetches a new one. TokenTransport calls it when Power BI
+// rejects a token with 401 Unauthorized.
+func (c *CachedTokenProvider) Invalidate(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token.AccessToken == token {
+		c.token = Token{}
+	}
+}
+
+// refreshLocked starts fetching a new token unless a fetch is already in
+// flight, and returns the in-flight call. c.mu must be held.
+func (c *CachedTokenProvider) refreshLocked(ctx context.Context) *tokenCall {
+	if c.inflight != nil {
+		return c.inflight
+	}
+	call := &tokenCall{done: make(chan struct{})}
+	c.inflight = call
+
+	// The fetch is shared by every waiting caller, so it must not be canceled
+	// when the caller that started it goes away.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		call.token, call.err = c.Provider.GetToken(ctx)
+
+		c.mu.Lock()
+		if call.err == nil {
+			c.token = call.token
+		}
+		c.inflight = nil
+		c.mu.Unlock()
+		close(call.done)
+	}()
+	return call
+}
+
+func (c *CachedTokenProvider) refreshBefore() time.Duration {
+	if c.RefreshBefore > 0 {
+		return c.RefreshBefore
+	}
+	return defaultRefreshBefore
+}
+
+// NewFromTokenProvider returns a new Power BI API client that authenticates
+// requests with tokens from p. Tokens are cached and refreshed ahead of their
+// expiry unless p is already a *CachedTokenProvider.
+func NewFromTokenProvider(p TokenProvider) *Client {
+	if _, ok := p.(*CachedTokenProvider); !ok {
+		p = NewCachedTokenProvider(p)
+	}
+	ts := TokenTransport{
+		Provider: p,
+	}
+
+	return NewClient(ts.Client())
+}
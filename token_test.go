@@ -0,0 +1,159 @@
+package powerbi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingProvider returns numbered tokens valid for ttl, after release is
+// closed if it is not nil.
+type countingProvider struct {
+	ttl     time.Duration
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (p *countingProvider) GetToken(ctx context.Context) (Token, error) {
+	if p.release != nil {
+		<-p.release
+	}
+	n := p.calls.Add(1)
+	return Token{AccessToken: fmt.Sprintf("t%d", n), ExpiresOn: time.Now().Add(p.ttl)}, nil
+}
+
+func TestCachedTokenProviderCaches(t *testing.T) {
+	p := &countingProvider{ttl: time.Hour}
+	c := NewCachedTokenProvider(p)
+	for range 3 {
+		tok, err := c.GetToken(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok.AccessToken != "t1" {
+			t.Fatalf("token = %q, want t1", tok.AccessToken)
+		}
+	}
+	if n := p.calls.Load(); n != 1 {
+		t.Errorf("provider calls = %d, want 1", n)
+	}
+}
+
+func TestCachedTokenProviderExpiry(t *testing.T) {
+	p := &countingProvider{ttl: -time.Second}
+	c := NewCachedTokenProvider(p)
+	for i := 1; i <= 2; i++ {
+		tok, err := c.GetToken(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("t%d", i); tok.AccessToken != want {
+			t.Errorf("call %d: token = %q, want %q", i, tok.AccessToken, want)
+		}
+	}
+}
+
+func TestCachedTokenProviderRefreshesAhead(t *testing.T) {
+	p := &countingProvider{ttl: time.Minute}
+	c := &CachedTokenProvider{Provider: p, RefreshBefore: 2 * time.Minute}
+	if _, err := c.GetToken(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The token is within RefreshBefore of its expiry: it is still returned
+	// while a new one is fetched in the background.
+	tok, err := c.GetToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "t1" {
+		t.Errorf("token = %q, want the cached t1", tok.AccessToken)
+	}
+	deadline := time.Now().Add(time.Second)
+	for p.calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("token was not refreshed in the background")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCachedTokenProviderSingleflight(t *testing.T) {
+	p := &countingProvider{ttl: time.Hour, release: make(chan struct{})}
+	c := NewCachedTokenProvider(p)
+
+	const callers = 50
+	var wg sync.WaitGroup
+	tokens := make([]string, callers)
+	for i := range callers {
+		wg.Go(func() {
+			tok, err := c.GetToken(context.Background())
+			if err != nil {
+				t.Error(err)
+			}
+			tokens[i] = tok.AccessToken
+		})
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(p.release)
+	wg.Wait()
+
+	if n := p.calls.Load(); n != 1 {
+		t.Errorf("provider calls = %d, want 1", n)
+	}
+	for i, tok := range tokens {
+		if tok != "t1" {
+			t.Errorf("caller %d: token = %q, want t1", i, tok)
+		}
+	}
+}
+
+func TestCachedTokenProviderCanceledWaiter(t *testing.T) {
+	p := &countingProvider{ttl: time.Hour, release: make(chan struct{})}
+	c := NewCachedTokenProvider(p)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetToken(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+
+	// The fetch started by the canceled caller still completes for others.
+	close(p.release)
+	tok, err := c.GetToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "t1" || p.calls.Load() != 1 {
+		t.Errorf("token = %q after %d calls, want t1 after 1", tok.AccessToken, p.calls.Load())
+	}
+}
+
+func TestTokenTransportRetriesUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t2" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	p := &countingProvider{ttl: time.Hour}
+	tr := &TokenTransport{Provider: NewCachedTokenProvider(p)}
+	resp, err := tr.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200 with the refreshed token", resp.StatusCode)
+	}
+	if n := p.calls.Load(); n != 2 {
+		t.Errorf("provider calls = %d, want 2", n)
+	}
+}
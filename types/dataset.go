@@ -139,6 +139,11 @@ type DatasetExecuteQueriesRequest struct {
 	ImpersonatedUserName string                                     `json:"impersonatedUserName"`
 	Queries              []DatasetExecuteQueriesQuery               `json:"queries"`
 	SerializerSettings   DatasetExecuteQueriesSerializationSettings `json:"serializerSettings"`
+
+	// AllowPartialResults makes ExecuteQueries return the response without
+	// a *DAXQueryError when it reports failed queries or tables, leaving the
+	// caller to inspect the Error fields. It is not sent to Power BI.
+	AllowPartialResults bool `json:"-"`
 }
 
 type DatasetExecuteQueriesResponse struct {
@@ -150,6 +155,13 @@ type DatasetExecuteQueriesResponse struct {
 type DatasetExecuteQueriesError struct {
 	Code    string `json:"code,omitempty"`
 	Message string `json:"message,omitempty"`
+	// PBIError holds the details of the error, such as the DAX error
+	// message.
+	PBIError *PBIError `json:"pbi.error,omitempty"`
+}
+
+func (e DatasetExecuteQueriesError) empty() bool {
+	return e.Code == "" && e.Message == "" && e.PBIError == nil
 }
 
 type DatasetExecuteQueriesInformationProtectionLabel struct {
@@ -254,11 +266,17 @@ const (
 	DaySaturday  Day = "Saturday"
 )
 
+// DirectQueryRefreshSchedule is the refresh schedule of a DirectQuery or
+// LiveConnection dataset. It refreshes either every Frequency minutes, or on
+// the given Days at the given Times.
 type DirectQueryRefreshSchedule struct {
-	Days            []Day    `json:"days"`
-	Frequency       int      `json:"frequency"`
-	LocalTimeZoneID string   `json:"localTimeZoneId"`
-	Times           []string `json:"times"`
+	Days []Day `json:"days"`
+	// Frequency is the interval between refreshes in minutes: 15, 30, 60,
+	// 120 or 180.
+	Frequency       int    `json:"frequency"`
+	LocalTimeZoneID string `json:"localTimeZoneId"`
+	// Times are the times of day of the refreshes in hh:mm format.
+	Times []string `json:"times"`
 }
 
 type MashupParameter struct {
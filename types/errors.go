@@ -1,18 +1,94 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type ErrHTTP struct {
 	Code    int
 	Message string
+
+	// ErrorCode is the machine-readable Power BI error code, such as
+	// "PowerBIEntityNotFound" or "ItemNotFound".
+	ErrorCode string
+
+	// ErrorMessage is the human-readable message of the error payload, if any.
+	ErrorMessage string
+
+	// Details lists the inner errors of the error payload.
+	Details []ErrorDetail
+
+	// RequestID is the Power BI request ID to quote to Microsoft support.
+	RequestID string
+
+	// RetryAfter is the delay requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+// ErrorDetail is an inner error of a Power BI error payload.
+type ErrorDetail struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
+
+// errorEnvelope is the JSON error payload returned by the Power BI REST APIs.
+// Errors raised by the Power BI backend carry their details in "pbi.error".
+type errorEnvelope struct {
+	Error struct {
+		Code    string        `json:"code"`
+		Message string        `json:"message"`
+		Details []ErrorDetail `json:"details"`
+		PBI     *PBIError     `json:"pbi.error"`
+	} `json:"error"`
+}
+
+// PBIError is the "pbi.error" object of the errors raised by the Power BI
+// backend. Its details hold the messages of failed DAX queries.
+type PBIError struct {
+	Code    string           `json:"code,omitempty"`
+	Details []PBIErrorDetail `json:"details,omitempty"`
+}
+
+// PBIErrorDetail is a detail of a PBIError, such as the "DetailsMessage" of a
+// DAX error.
+type PBIErrorDetail struct {
+	Code   string              `json:"code,omitempty"`
+	Detail PBIErrorDetailValue `json:"detail"`
+}
+
+// PBIErrorDetailValue is the value of a PBIErrorDetail.
+type PBIErrorDetailValue struct {
+	Type  int    `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// detailsMessageCode is the code of the PBIErrorDetail holding the message
+// of a DAX error.
+const detailsMessageCode = "DetailsMessage"
+
+// errorDetails returns the details of e as ErrorDetails.
+func (e *PBIError) errorDetails() []ErrorDetail {
+	if e == nil {
+		return nil
+	}
+	details := make([]ErrorDetail, len(e.Details))
+	for i, d := range e.Details {
+		details[i] = ErrorDetail{Code: d.Code, Message: d.Detail.Value}
+	}
+	return details
 }
 
 func (e *ErrHTTP) Error() string {
-	return fmt.Sprintf("error code %d (%s): %s", e.Code, http.StatusText(e.Code), e.Message)
+	msg := fmt.Sprintf("error code %d (%s): %s", e.Code, http.StatusText(e.Code), e.Message)
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
 }
 
 func NewErrHTTP(code int, message string) *ErrHTTP {
@@ -22,6 +98,30 @@ func NewErrHTTP(code int, message string) *ErrHTTP {
 	}
 }
 
+// ParseErrHTTP returns an ErrHTTP for the given status code and response body,
+// decoding the Power BI JSON error payload when the body contains one.
+func ParseErrHTTP(code int, body []byte) *ErrHTTP {
+	e := NewErrHTTP(code, string(body))
+	if len(e.Message) == 0 {
+		e.Message = fmt.Sprintf("%d %s", code, http.StatusText(code))
+	}
+
+	var env errorEnvelope
+	if json.Unmarshal(body, &env) != nil {
+		return e
+	}
+	e.ErrorCode = env.Error.Code
+	e.ErrorMessage = env.Error.Message
+	e.Details = env.Error.Details
+	if pbi := env.Error.PBI; pbi != nil {
+		if e.ErrorCode == "" {
+			e.ErrorCode = pbi.Code
+		}
+		e.Details = append(e.Details, pbi.errorDetails()...)
+	}
+	return e
+}
+
 func NewErrBadRequest(message string, args ...any) *ErrHTTP {
 	return NewErrHTTP(http.StatusBadRequest, fmt.Sprintf(message, args...))
 }
@@ -37,6 +137,205 @@ func NewErrNotFound(message string, args ...any) *ErrHTTP {
 }
 
 func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsThrottled reports whether err is a 429 Too Many Requests error.
+func IsThrottled(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsUnauthorized reports whether err is a 401 Unauthorized error.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 Forbidden error.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether err is a 409 Conflict error.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// HasErrorCode reports whether err is an ErrHTTP whose Power BI error code, or
+// the code of one of its details, equals code, a RefreshError whose service
+// exception has the error code code, or a DAXQueryError with the code code.
+func HasErrorCode(err error, code string) bool {
+	if code == "" {
+		return false
+	}
+	var daxErr *DAXQueryError
+	if errors.As(err, &daxErr) {
+		return daxErr.Code == code
+	}
+	var refreshErr *RefreshError
+	if errors.As(err, &refreshErr) {
+		return refreshErr.ServiceException != nil && refreshErr.ServiceException.ErrorCode == code
+	}
 	var errHTTP *ErrHTTP
-	return errors.As(err, &errHTTP) && errHTTP.Code == http.StatusNotFound
+	if !errors.As(err, &errHTTP) {
+		return false
+	}
+	if errHTTP.ErrorCode == code {
+		return true
+	}
+	for _, d := range errHTTP.Details {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func hasStatus(err error, code int) bool {
+	var errHTTP *ErrHTTP
+	return errors.As(err, &errHTTP) && errHTTP.Code == code
+}
+
+// RefreshError is returned when a dataset refresh that was waited for ends in
+// a status other than Completed.
+type RefreshError struct {
+	// RefreshID is the ID of the refresh.
+	RefreshID string
+
+	// Status is the final status of the refresh.
+	Status RefreshStatus
+
+	// ServiceExceptionJSON is the raw error of the refresh, if any.
+	ServiceExceptionJSON string
+
+	// ServiceException is ServiceExceptionJSON decoded, if it could be.
+	ServiceException *RefreshServiceException
+
+	// Refresh is the refresh history entry of the refresh.
+	Refresh *Refresh
+}
+
+// NewRefreshError returns the RefreshError of the refresh history entry r.
+func NewRefreshError(r *Refresh) *RefreshError {
+	return &RefreshError{
+		RefreshID:            r.RequestID,
+		Status:               r.Status,
+		ServiceExceptionJSON: r.ServiceExceptionJSON,
+		ServiceException:     r.ServiceException(),
+		Refresh:              r,
+	}
+}
+
+func (e *RefreshError) Error() string {
+	msg := fmt.Sprintf("refresh %s ended with status %s", e.RefreshID, e.Status)
+	switch se := e.ServiceException; {
+	case se != nil && se.ErrorDescription != "":
+		msg += fmt.Sprintf(": %s: %s", se.ErrorCode, se.ErrorDescription)
+	case se != nil:
+		msg += ": " + se.ErrorCode
+	case e.ServiceExceptionJSON != "":
+		msg += ": " + e.ServiceExceptionJSON
+	}
+	return msg
+}
+
+// DAXQueryError is returned by ExecuteQueries when Power BI answers with a
+// success status but reports a failed query in the response body, either for
+// the whole request, for a query or for one of its tables.
+type DAXQueryError struct {
+	// Code is the Power BI error code, such as "DatasetExecuteQueriesError".
+	Code string
+
+	// Message describes the failure.
+	Message string
+
+	// Details lists the details of the "pbi.error" object of the error, such
+	// as the "DetailsMessage" that holds the DAX error message.
+	Details []ErrorDetail
+
+	// QueryIndex is the index of the failed query in the request, or -1 if
+	// the error is about the whole request.
+	QueryIndex int
+
+	// TableIndex is the index of the failed table in the query result, or -1
+	// if the error is about the whole query or request.
+	TableIndex int
+
+	// Query is the DAX of the failed query, if QueryIndex is set.
+	Query string
+
+	// Response is the response that reported the error.
+	Response *DatasetExecuteQueriesResponse
+}
+
+// NewDAXQueryError returns the DAXQueryError of the first error reported in
+// resp, from the request level to the table level, or nil if resp reports
+// none.
+func NewDAXQueryError(req DatasetExecuteQueriesRequest, resp *DatasetExecuteQueriesResponse) *DAXQueryError {
+	if resp == nil {
+		return nil
+	}
+	newError := func(e DatasetExecuteQueriesError, query, table int) *DAXQueryError {
+		err := &DAXQueryError{Code: e.Code, Message: e.Message, Details: e.PBIError.errorDetails(), QueryIndex: query, TableIndex: table, Response: resp}
+		if err.Code == "" && e.PBIError != nil {
+			err.Code = e.PBIError.Code
+		}
+		if query >= 0 && query < len(req.Queries) {
+			err.Query = req.Queries[query].Query
+		}
+		return err
+	}
+	if !resp.Error.empty() {
+		return newError(resp.Error, -1, -1)
+	}
+	for i, result := range resp.Results {
+		if !result.Error.empty() {
+			return newError(result.Error, i, -1)
+		}
+		for j, table := range result.Tables {
+			if !table.Error.empty() {
+				return newError(table.Error, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *DAXQueryError) Error() string {
+	msg := "DAX query failed"
+	switch {
+	case e.TableIndex >= 0:
+		msg = fmt.Sprintf("DAX query %d failed on table %d", e.QueryIndex, e.TableIndex)
+	case e.QueryIndex >= 0:
+		msg = fmt.Sprintf("DAX query %d failed", e.QueryIndex)
+	}
+	switch {
+	case e.Code != "" && e.Message != "":
+		msg += fmt.Sprintf(": %s: %s", e.Code, e.Message)
+	case e.Code != "":
+		msg += ": " + e.Code
+	case e.Message != "":
+		msg += ": " + e.Message
+	}
+	for _, d := range e.Details {
+		if d.Code == detailsMessageCode && d.Message != "" && d.Message != e.Message {
+			msg += ": " + d.Message
+		}
+	}
+	return msg
+}
+
+// QueryTruncatedError is returned when the result of a DAX query may have been
+// truncated by the ExecuteQueries limits of 100,000 rows and 1,000,000 values
+// per query.
+type QueryTruncatedError struct {
+	// Query is the DAX of the query.
+	Query string
+
+	// Rows and Values are the number of rows and values of the result.
+	Rows   int
+	Values int
+}
+
+func (e *QueryTruncatedError) Error() string {
+	return fmt.Sprintf("DAX query result of %d rows and %d values reached the ExecuteQueries limits and may be truncated", e.Rows, e.Values)
 }
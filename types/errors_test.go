@@ -0,0 +1,178 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNewDAXQueryErrorDecodesPBIError(t *testing.T) {
+	const body = `{
+		"results": [{
+			"error": {
+				"code": "DatasetExecuteQueriesError",
+				"pbi.error": {
+					"code": "DatasetExecuteQueriesError",
+					"details": [
+						{"code": "DetailsMessage", "detail": {"type": 1, "value": "Query (1, 10) Cannot find table 'Sale'."}},
+						{"code": "AnalysisServicesErrorCode", "detail": {"type": 1, "value": "3241803789"}}
+					]
+				}
+			}
+		}]
+	}`
+	var resp DatasetExecuteQueriesResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+	req := DatasetExecuteQueriesRequest{Queries: []DatasetExecuteQueriesQuery{{Query: "EVALUATE Sale"}}}
+
+	err := NewDAXQueryError(req, &resp)
+	if err == nil {
+		t.Fatal("NewDAXQueryError() = nil")
+	}
+	if err.Code != "DatasetExecuteQueriesError" || err.QueryIndex != 0 || err.Query != "EVALUATE Sale" || len(err.Details) != 2 {
+		t.Errorf("NewDAXQueryError() = %+v", err)
+	}
+	const want = "DAX query 0 failed: DatasetExecuteQueriesError: Query (1, 10) Cannot find table 'Sale'."
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !HasErrorCode(err, "DatasetExecuteQueriesError") {
+		t.Error("HasErrorCode() = false")
+	}
+}
+
+func TestParseErrHTTPDecodesPBIError(t *testing.T) {
+	body := []byte(`{"error":{"code":"","pbi.error":{"code":"PowerBIEntityNotFound","details":[{"code":"DetailsMessage","detail":{"value":"not found"}}]}}}`)
+	err := ParseErrHTTP(404, body)
+	if err.ErrorCode != "PowerBIEntityNotFound" || len(err.Details) != 1 || err.Details[0].Message != "not found" {
+		t.Errorf("ParseErrHTTP() = %+v", err)
+	}
+}
+
+func TestParseErrHTTP(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         int
+		body         string
+		wantMessage  string
+		wantCode     string
+		wantErrorMsg string
+		wantDetails  []ErrorDetail
+	}{
+		{
+			name:         "error.code",
+			code:         http.StatusBadRequest,
+			body:         `{"error":{"code":"InvalidRequest","message":"Invalid dataset","details":[{"code":"Field","message":"bad name","target":"name"}]}}`,
+			wantCode:     "InvalidRequest",
+			wantErrorMsg: "Invalid dataset",
+			wantDetails:  []ErrorDetail{{Code: "Field", Message: "bad name", Target: "name"}},
+		},
+		{
+			name:        "pbi.error",
+			code:        http.StatusNotFound,
+			body:        `{"error":{"pbi.error":{"code":"PowerBIEntityNotFound","details":[{"code":"DetailsMessage","detail":{"type":1,"value":"not found"}}]}}}`,
+			wantCode:    "PowerBIEntityNotFound",
+			wantDetails: []ErrorDetail{{Code: "DetailsMessage", Message: "not found"}},
+		},
+		{
+			name:        "both",
+			code:        http.StatusBadRequest,
+			body:        `{"error":{"code":"DatasetExecuteQueriesError","details":[{"code":"A"}],"pbi.error":{"code":"Other","details":[{"code":"B"}]}}}`,
+			wantCode:    "DatasetExecuteQueriesError",
+			wantDetails: []ErrorDetail{{Code: "A"}, {Code: "B"}},
+		},
+		{
+			name:        "not JSON",
+			code:        http.StatusBadGateway,
+			body:        `<html>Bad Gateway</html>`,
+			wantMessage: `<html>Bad Gateway</html>`,
+		},
+		{
+			name:        "empty",
+			code:        http.StatusServiceUnavailable,
+			wantMessage: "503 Service Unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseErrHTTP(tt.code, []byte(tt.body))
+			wantMessage := tt.wantMessage
+			if wantMessage == "" {
+				wantMessage = tt.body
+			}
+			if err.Code != tt.code || err.Message != wantMessage {
+				t.Errorf("ParseErrHTTP() = %d %q, want %d %q", err.Code, err.Message, tt.code, wantMessage)
+			}
+			if err.ErrorCode != tt.wantCode || err.ErrorMessage != tt.wantErrorMsg {
+				t.Errorf("ParseErrHTTP() error code %q message %q, want %q %q", err.ErrorCode, err.ErrorMessage, tt.wantCode, tt.wantErrorMsg)
+			}
+			if fmt.Sprint(err.Details) != fmt.Sprint(tt.wantDetails) {
+				t.Errorf("ParseErrHTTP() details %+v, want %+v", err.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestErrHTTPPredicates(t *testing.T) {
+	predicates := map[string]struct {
+		is   func(error) bool
+		code int
+	}{
+		"IsNotFound":     {IsNotFound, http.StatusNotFound},
+		"IsThrottled":    {IsThrottled, http.StatusTooManyRequests},
+		"IsUnauthorized": {IsUnauthorized, http.StatusUnauthorized},
+		"IsForbidden":    {IsForbidden, http.StatusForbidden},
+		"IsConflict":     {IsConflict, http.StatusConflict},
+	}
+	codes := []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests, http.StatusInternalServerError}
+	for name, p := range predicates {
+		for _, code := range codes {
+			err := fmt.Errorf("powerbi: %w", NewErrHTTP(code, "failed"))
+			if got := p.is(err); got != (code == p.code) {
+				t.Errorf("%s(%d error) = %v", name, code, got)
+			}
+		}
+		if p.is(nil) || p.is(fmt.Errorf("%d", p.code)) {
+			t.Errorf("%s() is true for an error other than an ErrHTTP", name)
+		}
+	}
+}
+
+func TestHasErrorCode(t *testing.T) {
+	errHTTP := ParseErrHTTP(http.StatusBadRequest, []byte(`{"error":{"code":"InvalidRequest","details":[{"code":"FieldInvalid"}]}}`))
+	refreshErr := NewRefreshError(&Refresh{Status: RefreshStatusFailed, ServiceExceptionJSON: `{"errorCode":"ModelRefreshFailed"}`})
+	tests := []struct {
+		err  error
+		code string
+		want bool
+	}{
+		{errHTTP, "InvalidRequest", true},
+		{fmt.Errorf("wrapped: %w", errHTTP), "FieldInvalid", true},
+		{errHTTP, "Other", false},
+		{errHTTP, "", false},
+		{NewErrHTTP(http.StatusBadRequest, "no code"), "", false},
+		{refreshErr, "ModelRefreshFailed", true},
+		{NewRefreshError(&Refresh{Status: RefreshStatusCancelled}), "ModelRefreshFailed", false},
+		{&DAXQueryError{Code: "DatasetExecuteQueriesError"}, "DatasetExecuteQueriesError", true},
+		{fmt.Errorf("InvalidRequest"), "InvalidRequest", false},
+	}
+	for _, tt := range tests {
+		if got := HasErrorCode(tt.err, tt.code); got != tt.want {
+			t.Errorf("HasErrorCode(%v, %q) = %v, want %v", tt.err, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestErrHTTPError(t *testing.T) {
+	err := NewErrHTTP(http.StatusNotFound, "no dataset")
+	if got, want := err.Error(), "error code 404 (Not Found): no dataset"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	err.RequestID = "r1"
+	if got, want := err.Error(), "error code 404 (Not Found): no dataset (request id r1)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UpdateMashupParameterDetails sets a dataset parameter to a new value.
+type UpdateMashupParameterDetails struct {
+	Name     string `json:"name"`
+	NewValue string `json:"newValue"`
+}
+
+// UpdateMashupParametersRequest updates the parameters of a dataset.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-parameters-in-group#request-body
+type UpdateMashupParametersRequest struct {
+	UpdateDetails []UpdateMashupParameterDetails `json:"updateDetails"`
+}
+
+// UpdateDatasourceConnectionRequest replaces the connection details of the
+// data source matched by DatasourceSelector.
+type UpdateDatasourceConnectionRequest struct {
+	ConnectionDetails  *DatasourceConnectionDetails `json:"connectionDetails"`
+	DatasourceSelector Datasource                   `json:"datasourceSelector"`
+}
+
+// UpdateDatasourcesRequest updates the data sources of a dataset.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-datasources-in-group#request-body
+type UpdateDatasourcesRequest struct {
+	UpdateDetails []UpdateDatasourceConnectionRequest `json:"updateDetails"`
+}
+
+// SetAllConnectionsRequest sets the connection string of all the connections
+// of a dataset with a single DirectQuery data source.
+type SetAllConnectionsRequest struct {
+	ConnectionString string `json:"connectionString"`
+}
+
+// mashupDateLayouts are the accepted layouts of date, time and date time
+// parameter values.
+var mashupDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 15:04:05",
+	"1/2/2006",
+	"15:04:05",
+	"15:04",
+	"3:04:05 PM",
+}
+
+// ValidateParameterUpdates checks updates against the current parameters of a
+// dataset: every updated parameter must exist and be updated once, required
+// parameters cannot be emptied, and new values must parse as the type of
+// their parameter.
+func ValidateParameterUpdates(params []MashupParameter, updates []UpdateMashupParameterDetails) error {
+	if len(updates) == 0 {
+		return errors.New("no parameter updates")
+	}
+	byName := make(map[string]MashupParameter, len(params))
+	for _, p := range params {
+		byName[p.Name] = p
+	}
+	seen := make(map[string]bool, len(updates))
+	for _, u := range updates {
+		p, ok := byName[u.Name]
+		if !ok {
+			return fmt.Errorf("dataset has no parameter %q", u.Name)
+		}
+		if seen[u.Name] {
+			return fmt.Errorf("parameter %q is updated more than once", u.Name)
+		}
+		seen[u.Name] = true
+		if err := p.ValidateValue(u.NewValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks that v is a valid value of p: not empty if p is
+// required, and parsing as the type of p. Values of unknown types are
+// accepted.
+func (p MashupParameter) ValidateValue(v string) error {
+	if v == "" {
+		if p.IsRequired {
+			return fmt.Errorf("parameter %q is required", p.Name)
+		}
+		return nil
+	}
+
+	var err error
+	switch normalizeMashupType(p.Type) {
+	case "number", "decimalnumber", "fixeddecimalnumber", "currency", "percentage", "double", "decimal":
+		if _, perr := strconv.ParseFloat(v, 64); perr != nil {
+			err = errors.New("not a number")
+		}
+	case "wholenumber", "int64", "integer":
+		if _, perr := strconv.ParseInt(v, 10, 64); perr != nil {
+			err = errors.New("not a whole number")
+		}
+	case "logical", "truefalse", "boolean":
+		if !strings.EqualFold(v, "true") && !strings.EqualFold(v, "false") {
+			err = errors.New("want true or false")
+		}
+	case "date", "datetime", "datetimezone", "time":
+		err = parseMashupDate(v)
+	case "duration":
+		err = parseMashupDuration(v)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for parameter %q of type %s: %v", v, p.Name, p.Type, err)
+	}
+	return nil
+}
+
+// normalizeMashupType lowercases t and removes the spaces and slashes of the
+// Power Query type names, such as "Date/Time" and "Decimal Number".
+func normalizeMashupType(t string) string {
+	return strings.NewReplacer(" ", "", "/", "").Replace(strings.ToLower(t))
+}
+
+func parseMashupDate(v string) error {
+	for _, layout := range mashupDateLayouts {
+		if _, err := time.Parse(layout, v); err == nil {
+			return nil
+		}
+	}
+	return errors.New("not a date or time")
+}
+
+// parseMashupDuration accepts Power Query durations, formatted as
+// [d.]hh:mm:ss, and Go durations.
+func parseMashupDuration(v string) error {
+	if _, err := time.ParseDuration(v); err == nil {
+		return nil
+	}
+	clock := v
+	if days, rest, ok := strings.Cut(v, "."); ok && strings.Count(rest, ":") == 2 {
+		if _, err := strconv.Atoi(days); err != nil {
+			return errors.New("not a duration")
+		}
+		clock = rest
+	}
+	if _, err := time.Parse("15:04:05", clock); err != nil {
+		return errors.New("not a duration")
+	}
+	return nil
+}
+
+// Validate checks that every update selects a data source by type and
+// connection details and has new connection details.
+func (r UpdateDatasourcesRequest) Validate() error {
+	if len(r.UpdateDetails) == 0 {
+		return errors.New("no data source updates")
+	}
+	for i, u := range r.UpdateDetails {
+		switch {
+		case u.DatasourceSelector.DatasourceType == "":
+			return fmt.Errorf("data source update %d has no selector data source type", i)
+		case u.DatasourceSelector.ConnectionDetails == nil:
+			return fmt.Errorf("data source update %d has no selector connection details", i)
+		case u.ConnectionDetails == nil:
+			return fmt.Errorf("data source update %d has no new connection details", i)
+		}
+	}
+	return nil
+}
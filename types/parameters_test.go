@@ -0,0 +1,94 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMashupParameterValidateValue(t *testing.T) {
+	tests := []struct {
+		typ      string
+		required bool
+		value    string
+		wantErr  string
+	}{
+		{"Text", false, "", ""},
+		{"Text", true, "", "required"},
+		{"Text", true, "sql.contoso.com", ""},
+		{"Number", false, "", ""},
+		{"Number", false, "1.5", ""},
+		{"Decimal Number", false, "-2e3", ""},
+		{"Currency", false, "12,5", "not a number"},
+		{"Whole Number", false, "42", ""},
+		{"Int64", false, "4.2", "not a whole number"},
+		{"Logical", false, "TRUE", ""},
+		{"True/False", false, "false", ""},
+		{"Boolean", false, "yes", "want true or false"},
+		{"Date", false, "2024-01-31", ""},
+		{"Date/Time", false, "2024-01-31T12:30:00", ""},
+		{"DateTimeZone", false, "2024-01-31T12:30:00+01:00", ""},
+		{"DateTime", false, "1/31/2024 3:04:05 PM", ""},
+		{"Time", false, "15:04", ""},
+		{"Date", false, "31.01.2024", "not a date or time"},
+		{"Duration", false, "1.02:03:04", ""},
+		{"Duration", false, "02:03:04", ""},
+		{"Duration", false, "90m", ""},
+		{"Duration", false, "x.02:03:04", "not a duration"},
+		{"Duration", false, "2 hours", "not a duration"},
+		{"Binary", false, "anything", ""},
+	}
+	for _, tt := range tests {
+		p := MashupParameter{Name: "P", Type: tt.typ, IsRequired: tt.required}
+		err := p.ValidateValue(tt.value)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s %q: ValidateValue() = %v, want error containing %q", tt.typ, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateParameterUpdates(t *testing.T) {
+	params := []MashupParameter{
+		{Name: "Server", Type: "Text", IsRequired: true},
+		{Name: "Days", Type: "Whole Number"},
+	}
+	tests := []struct {
+		updates []UpdateMashupParameterDetails
+		wantErr string
+	}{
+		{[]UpdateMashupParameterDetails{{Name: "Server", NewValue: "sql"}, {Name: "Days", NewValue: "7"}}, ""},
+		{[]UpdateMashupParameterDetails{{Name: "Days", NewValue: ""}}, ""},
+		{nil, "no parameter updates"},
+		{[]UpdateMashupParameterDetails{{Name: "Port", NewValue: "1433"}}, `no parameter "Port"`},
+		{[]UpdateMashupParameterDetails{{Name: "Days", NewValue: "7"}, {Name: "Days", NewValue: "8"}}, "more than once"},
+		{[]UpdateMashupParameterDetails{{Name: "Server", NewValue: ""}}, "required"},
+		{[]UpdateMashupParameterDetails{{Name: "Days", NewValue: "a week"}}, "not a whole number"},
+	}
+	for _, tt := range tests {
+		err := ValidateParameterUpdates(params, tt.updates)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%+v: ValidateParameterUpdates() = %v, want error containing %q", tt.updates, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUpdateDatasourcesRequestValidate(t *testing.T) {
+	details := &DatasourceConnectionDetails{Server: "sql", Database: "sales"}
+	tests := []struct {
+		update  UpdateDatasourceConnectionRequest
+		wantErr string
+	}{
+		{UpdateDatasourceConnectionRequest{ConnectionDetails: details, DatasourceSelector: Datasource{DatasourceType: "Sql", ConnectionDetails: details}}, ""},
+		{UpdateDatasourceConnectionRequest{ConnectionDetails: details, DatasourceSelector: Datasource{ConnectionDetails: details}}, "data source type"},
+		{UpdateDatasourceConnectionRequest{ConnectionDetails: details, DatasourceSelector: Datasource{DatasourceType: "Sql"}}, "selector connection details"},
+		{UpdateDatasourceConnectionRequest{DatasourceSelector: Datasource{DatasourceType: "Sql", ConnectionDetails: details}}, "new connection details"},
+	}
+	for _, tt := range tests {
+		err := UpdateDatasourcesRequest{UpdateDetails: []UpdateDatasourceConnectionRequest{tt.update}}.Validate()
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%+v: Validate() = %v, want error containing %q", tt.update, err, tt.wantErr)
+		}
+	}
+	if err := (UpdateDatasourcesRequest{}).Validate(); err == nil {
+		t.Error("Validate() of an empty request succeeded")
+	}
+}
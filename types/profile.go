@@ -0,0 +1,22 @@
+package types
+
+// CreateOrUpdateProfileRequest is the payload to create or update a service principal profile.
+type CreateOrUpdateProfileRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+// ListProfilesOptions controls the query for listing service principal profiles.
+type ListProfilesOptions struct {
+	OrderBy string `url:"$orderBy,omitempty"`
+	Skip    int    `url:"$skip,omitempty"`
+	Top     int    `url:"$top,omitempty"`
+}
+
+// ServicePrincipalProfileList is a paged list of service principal profiles.
+type ServicePrincipalProfileList struct {
+	Value []ServicePrincipalProfile `json:"value"`
+}
+
+func (p ServicePrincipalProfile) String() string {
+	return Stringify(p)
+}
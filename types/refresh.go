@@ -0,0 +1,195 @@
+package types
+
+import "encoding/json"
+
+// NotifyOption is the mail notification option of a dataset refresh.
+type NotifyOption string
+
+const (
+	NotifyOptionMailOnCompletion NotifyOption = "MailOnCompletion"
+	NotifyOptionMailOnFailure    NotifyOption = "MailOnFailure"
+	NotifyOptionNoNotification   NotifyOption = "NoNotification"
+)
+
+// DatasetRefreshType is the type of processing of an enhanced refresh.
+type DatasetRefreshType string
+
+const (
+	DatasetRefreshTypeAutomatic   DatasetRefreshType = "Automatic"
+	DatasetRefreshTypeCalculate   DatasetRefreshType = "Calculate"
+	DatasetRefreshTypeClearValues DatasetRefreshType = "ClearValues"
+	DatasetRefreshTypeDataOnly    DatasetRefreshType = "DataOnly"
+	DatasetRefreshTypeDefragment  DatasetRefreshType = "Defragment"
+	DatasetRefreshTypeFull        DatasetRefreshType = "Full"
+)
+
+// DatasetCommitMode determines whether an enhanced refresh commits objects
+// in batches or only when complete.
+type DatasetCommitMode string
+
+const (
+	DatasetCommitModePartialBatch  DatasetCommitMode = "PartialBatch"
+	DatasetCommitModeTransactional DatasetCommitMode = "Transactional"
+)
+
+// DatasetRefreshObjects is a table or partition to refresh.
+type DatasetRefreshObjects struct {
+	Table     string `json:"table"`
+	Partition string `json:"partition,omitempty"`
+}
+
+// DatasetRefreshRequest triggers a refresh. Setting any field other than
+// NotifyOption makes it an enhanced refresh, which requires a Premium,
+// Premium Per User or Fabric capacity and does not support NotifyOption.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/refresh-dataset-in-group#request-body
+type DatasetRefreshRequest struct {
+	NotifyOption NotifyOption `json:"notifyOption,omitempty"`
+
+	ApplyRefreshPolicy *bool                   `json:"applyRefreshPolicy,omitempty"`
+	CommitMode         DatasetCommitMode       `json:"commitMode,omitempty"`
+	EffectiveDate      string                  `json:"effectiveDate,omitempty"`
+	MaxParallelism     int                     `json:"maxParallelism,omitempty"`
+	Objects            []DatasetRefreshObjects `json:"objects,omitempty"`
+	RetryCount         int                     `json:"retryCount,omitempty"`
+	// Timeout is the timeout of a single refresh attempt, in hh:mm:ss format.
+	Timeout string             `json:"timeout,omitempty"`
+	Type    DatasetRefreshType `json:"type,omitempty"`
+}
+
+// RefreshStatus is the status of a refresh, or of an object refreshed by an
+// enhanced refresh. The refresh history reports refreshes in progress as
+// RefreshStatusUnknown.
+type RefreshStatus string
+
+const (
+	RefreshStatusUnknown    RefreshStatus = "Unknown"
+	RefreshStatusNotStarted RefreshStatus = "NotStarted"
+	RefreshStatusInProgress RefreshStatus = "InProgress"
+	RefreshStatusCompleted  RefreshStatus = "Completed"
+	RefreshStatusTimedOut   RefreshStatus = "TimedOut"
+	RefreshStatusFailed     RefreshStatus = "Failed"
+	RefreshStatusDisabled   RefreshStatus = "Disabled"
+	RefreshStatusCancelled  RefreshStatus = "Cancelled"
+)
+
+// Terminal reports whether s is the status of a refresh that has finished.
+func (s RefreshStatus) Terminal() bool {
+	switch s {
+	case RefreshStatusCompleted, RefreshStatusTimedOut, RefreshStatusFailed, RefreshStatusDisabled, RefreshStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// RefreshType is how a refresh was triggered.
+type RefreshType string
+
+const (
+	RefreshTypeOnDemand         RefreshType = "OnDemand"
+	RefreshTypeOnDemandTraining RefreshType = "OnDemandTraining"
+	RefreshTypeScheduled        RefreshType = "Scheduled"
+	RefreshTypeViaAPI           RefreshType = "ViaApi"
+	RefreshTypeViaEnhancedAPI   RefreshType = "ViaEnhancedApi"
+	RefreshTypeViaXMLAEndpoint  RefreshType = "ViaXmlaEndpoint"
+)
+
+// RefreshAttemptType is the type of a refresh attempt.
+type RefreshAttemptType string
+
+const (
+	RefreshAttemptTypeData  RefreshAttemptType = "Data"
+	RefreshAttemptTypeQuery RefreshAttemptType = "Query"
+)
+
+// RefreshAttempt is an attempt of a refresh.
+type RefreshAttempt struct {
+	AttemptID            int                `json:"attemptId"`
+	EndTime              Timestamp          `json:"endTime"`
+	ServiceExceptionJSON string             `json:"serviceExceptionJson,omitempty"`
+	StartTime            Timestamp          `json:"startTime"`
+	Type                 RefreshAttemptType `json:"type,omitempty"`
+}
+
+// Refresh is an entry of the refresh history of a dataset.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-history-in-group#refresh
+type Refresh struct {
+	EndTime     Timestamp   `json:"endTime"`
+	ID          int64       `json:"id"`
+	RefreshType RefreshType `json:"refreshType"`
+	// RequestID is the ID returned by RefreshDataset.
+	RequestID            string           `json:"requestId"`
+	RefreshAttempts      []RefreshAttempt `json:"refreshAttempts,omitempty"`
+	ServiceExceptionJSON string           `json:"serviceExceptionJson,omitempty"`
+	StartTime            Timestamp        `json:"startTime"`
+	Status               RefreshStatus    `json:"status"`
+}
+
+// ServiceException decodes the ServiceExceptionJSON of a failed refresh.
+func (r *Refresh) ServiceException() *RefreshServiceException {
+	return parseServiceException(r.ServiceExceptionJSON)
+}
+
+type RefreshList struct {
+	Value []Refresh `json:"value"`
+}
+
+type RefreshHistoryOptions struct {
+	Top int `url:"$top,omitempty"`
+}
+
+// RefreshServiceException is the error of a failed refresh.
+type RefreshServiceException struct {
+	ErrorCode        string `json:"errorCode"`
+	ErrorDescription string `json:"errorDescription,omitempty"`
+}
+
+func parseServiceException(data string) *RefreshServiceException {
+	if data == "" {
+		return nil
+	}
+	var e RefreshServiceException
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return nil
+	}
+	return &e
+}
+
+// DatasetRefreshMessageType is the severity of a message of an enhanced
+// refresh.
+type DatasetRefreshMessageType string
+
+const (
+	DatasetRefreshMessageTypeError   DatasetRefreshMessageType = "Error"
+	DatasetRefreshMessageTypeWarning DatasetRefreshMessageType = "Warning"
+)
+
+// DatasetRefreshMessage is a message of an enhanced refresh.
+type DatasetRefreshMessage struct {
+	Code    string                    `json:"code,omitempty"`
+	Message string                    `json:"message"`
+	Type    DatasetRefreshMessageType `json:"type,omitempty"`
+}
+
+// DatasetRefreshObjectDetail is the status of a table or partition refreshed
+// by an enhanced refresh.
+type DatasetRefreshObjectDetail struct {
+	Partition string        `json:"partition,omitempty"`
+	Status    RefreshStatus `json:"status"`
+	Table     string        `json:"table"`
+}
+
+// DatasetRefreshDetail is the execution detail of an enhanced refresh.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/get-refresh-execution-details-in-group#response
+type DatasetRefreshDetail struct {
+	CommitMode         DatasetCommitMode            `json:"commitMode,omitempty"`
+	CurrentRefreshType DatasetRefreshType           `json:"currentRefreshType,omitempty"`
+	EndTime            Timestamp                    `json:"endTime"`
+	ExtendedStatus     RefreshStatus                `json:"extendedStatus,omitempty"`
+	Messages           []DatasetRefreshMessage      `json:"messages,omitempty"`
+	NumberOfAttempts   int                          `json:"numberOfAttempts,omitempty"`
+	Objects            []DatasetRefreshObjectDetail `json:"objects,omitempty"`
+	RefreshAttempts    []RefreshAttempt             `json:"refreshAttempts,omitempty"`
+	StartTime          Timestamp                    `json:"startTime"`
+	Status             RefreshStatus                `json:"status"`
+	Type               DatasetRefreshType           `json:"type,omitempty"`
+}
@@ -0,0 +1,354 @@
+package types
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DecodeRows decodes the rows of an ExecuteQueries table into values of the
+// struct type T.
+//
+// Each row is a JSON object keyed by column names such as "Sales[Amount]" for
+// model columns and "[Total]" for measures and columns defined in the query.
+// A struct field receives the column named by its dax tag:
+//
+//	type sale struct {
+//		Region string    `dax:"Geography[Region]"`
+//		Day    time.Time `dax:"Date[Date]"`
+//		Total  float64   `dax:"[Total Sales]"`
+//		Orders *int      `dax:"Orders"`
+//		Notes  string    `dax:"-"`
+//	}
+//
+// A tag with a table name only matches the column of that table. A tag
+// without one, such as "[Total Sales]" or "Orders", matches the column of
+// that name of any table. Untagged fields match the column named after the
+// field, and fields tagged "-" are ignored. Names are case-insensitive, and
+// columns that match no field are ignored.
+//
+// Numbers decode into integer, floating-point and string fields, booleans
+// into bool and string fields, and ISO 8601 date times, with or without a
+// time zone, into time.Time and Timestamp fields. Fields of types implementing
+// json.Unmarshaler or encoding.TextUnmarshaler decode themselves. Null values,
+// returned when the query sets IncludeNulls, and missing columns leave fields
+// at their zero value, which is nil for pointers.
+//
+// Values that do not convert to the type of their field are reported in a
+// *DecodeRowsError, alongside all the rows, where these fields are left at
+// their zero value.
+func DecodeRows[T any](table DatasetExecuteQueriesTableResult) ([]T, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("types: cannot decode rows into %s, want a struct type", typ)
+	}
+	d := newRowDecoder(typ)
+
+	out := make([]T, len(table.Rows))
+	var errs []*ColumnError
+	for i, row := range table.Rows {
+		errs = append(errs, d.decode(i, row, reflect.ValueOf(&out[i]).Elem())...)
+	}
+	if len(errs) > 0 {
+		return out, &DecodeRowsError{Errors: errs}
+	}
+	return out, nil
+}
+
+// ColumnError is a value of a row of an ExecuteQueries table that could not
+// be decoded into its struct field.
+type ColumnError struct {
+	Row    int
+	Column string
+	Field  string
+	Value  any
+	Err    error
+}
+
+func (e *ColumnError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d, column %q into field %s: %v", e.Row, e.Column, e.Field, e.Err)
+}
+
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeRowsError lists the values DecodeRows could not decode, in row order.
+type DecodeRowsError struct {
+	Errors []*ColumnError
+}
+
+func (e *DecodeRowsError) Error() string {
+	msg := "types: " + e.Errors[0].Error()
+	if n := len(e.Errors) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more errors)", n)
+	}
+	return msg
+}
+
+func (e *DecodeRowsError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// rowField is a struct field DecodeRows decodes a column into.
+type rowField struct {
+	index  []int
+	name   string
+	table  string
+	column string
+}
+
+// rowDecoder decodes rows into a struct type. It resolves each column name
+// to its field once.
+type rowDecoder struct {
+	fields  []rowField
+	columns map[string]*rowField
+}
+
+func newRowDecoder(typ reflect.Type) *rowDecoder {
+	d := &rowDecoder{columns: make(map[string]*rowField)}
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous && isStruct(f.Type) {
+			continue
+		}
+		tag, ok := f.Tag.Lookup("dax")
+		if tag == "-" {
+			continue
+		}
+		if !ok || tag == "" {
+			tag = f.Name
+		}
+		table, column := splitColumnName(tag)
+		d.fields = append(d.fields, rowField{index: f.Index, name: f.Name, table: table, column: column})
+	}
+	return d
+}
+
+// isStruct reports whether typ is a struct or a pointer to a struct, whose
+// fields are promoted when embedded.
+func isStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// splitColumnName splits a column name such as "Sales[Amount]" or
+// "'Sales Order'[Amount]" into its table and column names.
+func splitColumnName(s string) (table, column string) {
+	open := strings.IndexByte(s, '[')
+	if open < 0 || !strings.HasSuffix(s, "]") {
+		return "", s
+	}
+	table = strings.TrimSpace(s[:open])
+	if len(table) >= 2 && table[0] == '\'' && table[len(table)-1] == '\'' {
+		table = strings.ReplaceAll(table[1:len(table)-1], "''", "'")
+	}
+	return table, s[open+1 : len(s)-1]
+}
+
+// field returns the field column decodes into, if any. A field tagged with
+// the table of the column takes precedence over a field tagged without one.
+func (d *rowDecoder) field(column string) *rowField {
+	if f, ok := d.columns[column]; ok {
+		return f
+	}
+	table, name := splitColumnName(column)
+	var match *rowField
+	for i := range d.fields {
+		f := &d.fields[i]
+		if !strings.EqualFold(f.column, name) {
+			continue
+		}
+		if f.table != "" && strings.EqualFold(f.table, table) {
+			match = f
+			break
+		}
+		if f.table == "" && match == nil {
+			match = f
+		}
+	}
+	d.columns[column] = match
+	return match
+}
+
+func (d *rowDecoder) decode(i int, row any, v reflect.Value) []*ColumnError {
+	values, ok := row.(map[string]any)
+	if !ok {
+		return []*ColumnError{{Row: i, Value: row, Err: fmt.Errorf("row is a %T, not an object", row)}}
+	}
+
+	var errs []*ColumnError
+	set := make(map[*rowField]string, len(values))
+	for column, value := range values {
+		f := d.field(column)
+		if f == nil {
+			continue
+		}
+		if prev, ok := set[f]; ok {
+			errs = append(errs, &ColumnError{Row: i, Column: column, Field: f.name, Value: value,
+				Err: fmt.Errorf("field already decoded from column %q", prev)})
+			continue
+		}
+		set[f] = column
+		fv, err := fieldByIndex(v, f.index)
+		if err == nil {
+			err = setRowValue(fv, value)
+		}
+		if err != nil {
+			errs = append(errs, &ColumnError{Row: i, Column: column, Field: f.name, Value: value, Err: err})
+		}
+	}
+	// Map iteration order is random; report errors in a stable order.
+	slices.SortFunc(errs, func(a, b *ColumnError) int {
+		return strings.Compare(a.Column, b.Column)
+	})
+	return errs
+}
+
+// fieldByIndex returns the field of v at index, allocating the nil pointers
+// to embedded structs it goes through.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// rowDateLayouts are the layouts of ExecuteQueries date times, which omit the
+// time zone.
+var rowDateLayouts = slices.Concat(timestampLayouts, []string{"2006-01-02"})
+
+// setRowValue converts value, as decoded from JSON, into v.
+func setRowValue(v reflect.Value, value any) error {
+	if value == nil {
+		v.SetZero()
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setRowValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		s, ok := value.(string)
+		if !ok {
+			return convertError(value, v.Type())
+		}
+		t, err := parseRowTime(s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if p := v.Addr(); p.Type().Implements(jsonUnmarshalerType) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return p.Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+	if p := v.Addr(); p.Type().Implements(textUnmarshalerType) {
+		s, ok := value.(string)
+		if !ok {
+			return convertError(value, v.Type())
+		}
+		return p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		switch x := value.(type) {
+		case string:
+			v.SetString(x)
+		case float64:
+			v.SetString(strconv.FormatFloat(x, 'f', -1, 64))
+		case bool:
+			v.SetString(strconv.FormatBool(x))
+		default:
+			return convertError(value, v.Type())
+		}
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return convertError(value, v.Type())
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || v.OverflowInt(int64(f)) {
+			return convertError(value, v.Type())
+		}
+		v.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || v.OverflowUint(uint64(f)) {
+			return convertError(value, v.Type())
+		}
+		v.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, ok := value.(float64)
+		if !ok || v.OverflowFloat(f) {
+			return convertError(value, v.Type())
+		}
+		v.SetFloat(f)
+	case reflect.Interface:
+		x := reflect.ValueOf(value)
+		if !x.Type().AssignableTo(v.Type()) {
+			return convertError(value, v.Type())
+		}
+		v.Set(x)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func parseRowTime(s string) (time.Time, error) {
+	for _, layout := range rowDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %q to a date time", s)
+}
+
+func convertError(value any, typ reflect.Type) error {
+	if s, ok := value.(string); ok {
+		return fmt.Errorf("cannot convert %q to %s", s, typ)
+	}
+	return fmt.Errorf("cannot convert %v (%T) to %s", value, value, typ)
+}
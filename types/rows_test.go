@@ -0,0 +1,176 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func table(rows ...map[string]any) DatasetExecuteQueriesTableResult {
+	var t DatasetExecuteQueriesTableResult
+	for _, r := range rows {
+		t.Rows = append(t.Rows, r)
+	}
+	return t
+}
+
+type level string
+
+func (l *level) UnmarshalText(text []byte) error {
+	*l = level(strings.ToUpper(string(text)))
+	return nil
+}
+
+func TestDecodeRows(t *testing.T) {
+	type sale struct {
+		Region  string    `dax:"Geography[Region]"`
+		Country string    `dax:"'Sales Territory'[Country]"`
+		Day     time.Time `dax:"Date[Date]"`
+		Total   float64   `dax:"[Total Sales]"`
+		Orders  *int      `dax:"Orders"`
+		Count   int64
+		Level   level  `dax:"[Level]"`
+		Code    string `dax:"[Code]"`
+		Notes   string `dax:"-"`
+		Any     any    `dax:"[Any]"`
+	}
+	got, err := DecodeRows[sale](table(map[string]any{
+		"Geography[Region]":          "EU",
+		"'Sales Territory'[Country]": "France",
+		"Date[Date]":                 "2024-01-31T00:00:00",
+		"[Total Sales]":              1234.5,
+		"Sales[Orders]":              7.0,
+		"[count]":                    3.0,
+		"[Level]":                    "high",
+		"[Code]":                     42.0,
+		"[Notes]":                    "ignored",
+		"[Any]":                      true,
+		"Other[Column]":              "ignored",
+	}, map[string]any{
+		"Geography[Region]": nil,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders := 7
+	want := sale{
+		Region: "EU", Country: "France", Day: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Total: 1234.5,
+		Orders: &orders, Count: 3, Level: "HIGH", Code: "42", Any: true,
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d rows, want 2", len(got))
+	}
+	if g := got[0]; g.Region != want.Region || g.Country != want.Country || !g.Day.Equal(want.Day) || g.Total != want.Total ||
+		g.Orders == nil || *g.Orders != 7 || g.Count != want.Count || g.Level != want.Level || g.Code != want.Code || g.Notes != "" || g.Any != true {
+		t.Errorf("row 0 = %+v, want %+v", g, want)
+	}
+	if g := got[1]; g != (sale{}) {
+		t.Errorf("row 1 = %+v, want the zero value", g)
+	}
+}
+
+func TestDecodeRowsTablePrecedence(t *testing.T) {
+	type row struct {
+		Any   string `dax:"Name"`
+		Table string `dax:"Product[Name]"`
+	}
+	got, err := DecodeRows[row](table(map[string]any{"Product[Name]": "Bike", "Customer[Name]": "Ann"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Table != "Bike" || got[0].Any != "Ann" {
+		t.Errorf("row = %+v, want Product[Name] in Table and Customer[Name] in Any", got[0])
+	}
+}
+
+func TestDecodeRowsErrors(t *testing.T) {
+	type row struct {
+		ID    int       `dax:"[ID]"`
+		Price float64   `dax:"[Price]"`
+		Day   time.Time `dax:"[Day]"`
+		Small int8      `dax:"[Small]"`
+	}
+	got, err := DecodeRows[row](table(
+		map[string]any{"[ID]": 1.5, "[Price]": "cheap", "[Day]": "yesterday"},
+		map[string]any{"[ID]": 2.0, "[Small]": 300.0},
+	))
+	var decErr *DecodeRowsError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("err = %v, want *DecodeRowsError", err)
+	}
+	var cols []string
+	for _, e := range decErr.Errors {
+		cols = append(cols, e.Column)
+	}
+	if got, want := strings.Join(cols, " "), "[Day] [ID] [Price] [Small]"; got != want {
+		t.Errorf("error columns = %s, want %s", got, want)
+	}
+	if decErr.Errors[3].Row != 1 || decErr.Errors[3].Field != "Small" {
+		t.Errorf("last error = %+v, want row 1 field Small", decErr.Errors[3])
+	}
+	if len(got) != 2 || got[1].ID != 2 {
+		t.Errorf("rows = %+v, want both rows with the values that converted", got)
+	}
+}
+
+func TestDecodeRowsNotObject(t *testing.T) {
+	type row struct{ A string }
+	var tbl DatasetExecuteQueriesTableResult
+	tbl.Rows = []any{"not an object"}
+	if _, err := DecodeRows[row](tbl); err == nil {
+		t.Fatal("want an error")
+	}
+	if _, err := DecodeRows[int](tbl); err == nil {
+		t.Fatal("want an error for a non-struct type")
+	}
+}
+
+type Base struct {
+	ID   string `dax:"[ID]"`
+	Name string `dax:"[Name]"`
+}
+
+type base struct {
+	Secret string `dax:"[Secret]"`
+}
+
+func TestDecodeRowsEmbedded(t *testing.T) {
+	type byValue struct {
+		Base
+		Total float64 `dax:"[Total]"`
+	}
+	type byPointer struct {
+		*Base
+		Total float64 `dax:"[Total]"`
+	}
+	row := map[string]any{"[ID]": "a1", "[Name]": "Ann", "[Total]": 5.0}
+
+	v, err := DecodeRows[byValue](table(row))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v[0].ID != "a1" || v[0].Name != "Ann" || v[0].Total != 5 {
+		t.Errorf("by value = %+v", v[0])
+	}
+
+	p, err := DecodeRows[byPointer](table(row, map[string]any{"[Total]": 1.0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p[0].Base == nil || p[0].ID != "a1" || p[0].Name != "Ann" || p[0].Total != 5 {
+		t.Errorf("by pointer = %+v", p[0])
+	}
+	if p[1].Base != nil {
+		t.Errorf("embedded pointer allocated for a row without its columns: %+v", p[1].Base)
+	}
+
+	type unexported struct {
+		*base
+	}
+	_, err = DecodeRows[unexported](table(map[string]any{"[Secret]": "s"}))
+	var decErr *DecodeRowsError
+	if !errors.As(err, &decErr) || decErr.Errors[0].Field != "Secret" {
+		t.Errorf("err = %v, want a column error for Secret", err)
+	}
+}
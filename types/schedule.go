@@ -0,0 +1,158 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// maxScheduleTimes is the maximum number of times of day of a refresh
+// schedule: one per half-hour slot.
+const maxScheduleTimes = 48
+
+// directQueryFrequencies are the valid refresh intervals of DirectQuery
+// refresh schedules, in minutes.
+var directQueryFrequencies = []int{15, 30, 60, 120, 180}
+
+// ScheduleNotifyOption is the notification option of a refresh schedule.
+type ScheduleNotifyOption string
+
+const (
+	ScheduleNotifyOptionMailOnFailure  ScheduleNotifyOption = "MailOnFailure"
+	ScheduleNotifyOptionNoNotification ScheduleNotifyOption = "NoNotification"
+)
+
+// RefreshSchedule is the refresh schedule of an import mode dataset. When
+// updating a schedule, empty fields are left unchanged.
+// https://learn.microsoft.com/en-us/rest/api/power-bi/datasets/update-refresh-schedule-in-group#refreshschedule
+type RefreshSchedule struct {
+	Days    []Day `json:"days,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// LocalTimeZoneID is the Windows ID of the time zone of Times, such as
+	// "UTC" or "Pacific Standard Time".
+	LocalTimeZoneID string               `json:"localTimeZoneId,omitempty"`
+	NotifyOption    ScheduleNotifyOption `json:"notifyOption,omitempty"`
+	// Times are the times of day of the refreshes in hh:mm format, on the
+	// hour or half past.
+	Times []string `json:"times,omitempty"`
+}
+
+// RefreshScheduleRequest is the body of a refresh schedule update.
+type RefreshScheduleRequest struct {
+	Value RefreshSchedule `json:"value"`
+}
+
+// DirectQueryRefreshScheduleRequest is the body of a DirectQuery refresh
+// schedule update.
+type DirectQueryRefreshScheduleRequest struct {
+	Value DirectQueryRefreshSchedule `json:"value"`
+}
+
+// directQueryRefreshScheduleUpdate is a DirectQueryRefreshSchedule without
+// its empty fields, as Power BI rejects a frequency sent along with days or
+// times, even empty ones.
+type directQueryRefreshScheduleUpdate struct {
+	Days            []Day    `json:"days,omitempty"`
+	Frequency       int      `json:"frequency,omitempty"`
+	LocalTimeZoneID string   `json:"localTimeZoneId,omitempty"`
+	Times           []string `json:"times,omitempty"`
+}
+
+// MarshalJSON omits the empty fields of the schedule.
+func (r DirectQueryRefreshScheduleRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Value directQueryRefreshScheduleUpdate `json:"value"`
+	}{directQueryRefreshScheduleUpdate(r.Value)})
+}
+
+// Validate reports the first problem of s that Power BI would reject.
+func (s RefreshSchedule) Validate() error {
+	if err := validateDays(s.Days); err != nil {
+		return err
+	}
+	if err := validateTimes(s.Times); err != nil {
+		return err
+	}
+	if err := validateTimeZone(s.LocalTimeZoneID); err != nil {
+		return err
+	}
+	switch s.NotifyOption {
+	case "", ScheduleNotifyOptionMailOnFailure, ScheduleNotifyOptionNoNotification:
+	default:
+		return fmt.Errorf("invalid refresh schedule notify option %q", s.NotifyOption)
+	}
+	return nil
+}
+
+// Validate reports the first problem of s that Power BI would reject.
+func (s DirectQueryRefreshSchedule) Validate() error {
+	if err := validateTimeZone(s.LocalTimeZoneID); err != nil {
+		return err
+	}
+	if s.Frequency != 0 {
+		if !slices.Contains(directQueryFrequencies, s.Frequency) {
+			return fmt.Errorf("invalid refresh schedule frequency %d, want one of %v minutes", s.Frequency, directQueryFrequencies)
+		}
+		if len(s.Days) > 0 || len(s.Times) > 0 {
+			return errors.New("refresh schedule frequency cannot be combined with days or times")
+		}
+		return nil
+	}
+	if err := validateDays(s.Days); err != nil {
+		return err
+	}
+	return validateTimes(s.Times)
+}
+
+func validateDays(days []Day) error {
+	for i, d := range days {
+		switch d {
+		case DaySunday, DayMonday, DayTuesday, DayWednesday, DayThursday, DayFriday, DaySaturday:
+		default:
+			return fmt.Errorf("invalid refresh schedule day %q", d)
+		}
+		if slices.Contains(days[:i], d) {
+			return fmt.Errorf("duplicate refresh schedule day %q", d)
+		}
+	}
+	return nil
+}
+
+func validateTimes(times []string) error {
+	if len(times) > maxScheduleTimes {
+		return fmt.Errorf("refresh schedule has %d times, more than %d", len(times), maxScheduleTimes)
+	}
+	for i, t := range times {
+		if !validScheduleTime(t) {
+			return fmt.Errorf("invalid refresh schedule time %q, want hh:00 or hh:30", t)
+		}
+		if slices.Contains(times[:i], t) {
+			return fmt.Errorf("duplicate refresh schedule time %q", t)
+		}
+	}
+	return nil
+}
+
+// validateTimeZone checks that id is a known Windows time zone ID, such as
+// "UTC" or "Pacific Standard Time". An empty ID leaves the time zone
+// unchanged.
+func validateTimeZone(id string) error {
+	if id != "" && !windowsTimeZones[id] {
+		return fmt.Errorf("unknown refresh schedule time zone ID %q, want a Windows time zone ID such as %q", id, "Pacific Standard Time")
+	}
+	return nil
+}
+
+// validScheduleTime reports whether t is a half-hour slot in hh:mm format.
+func validScheduleTime(t string) bool {
+	if len(t) != 5 || t[2] != ':' || !isDigit(t[0]) || !isDigit(t[1]) {
+		return false
+	}
+	hour := int(t[0]-'0')*10 + int(t[1]-'0')
+	return hour < 24 && (t[3:] == "00" || t[3:] == "30")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRefreshScheduleValidate(t *testing.T) {
+	tests := []struct {
+		schedule RefreshSchedule
+		wantErr  string
+	}{
+		{RefreshSchedule{Days: []Day{DayMonday}, Times: []string{"07:00", "19:30"}, LocalTimeZoneID: "UTC"}, ""},
+		{RefreshSchedule{LocalTimeZoneID: "Pacific Standard Time"}, ""},
+		{RefreshSchedule{}, ""},
+		{RefreshSchedule{LocalTimeZoneID: "America/Los_Angeles"}, "time zone"},
+		{RefreshSchedule{LocalTimeZoneID: " UTC"}, "time zone"},
+		{RefreshSchedule{Days: []Day{"Funday"}}, "day"},
+		{RefreshSchedule{Days: []Day{DayMonday, DayMonday}}, "duplicate"},
+		{RefreshSchedule{Times: []string{"07:15"}}, "time"},
+		{RefreshSchedule{NotifyOption: "Always"}, "notify"},
+	}
+	for _, tt := range tests {
+		err := tt.schedule.Validate()
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%+v: Validate() = %v, want error containing %q", tt.schedule, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDirectQueryRefreshScheduleValidate(t *testing.T) {
+	tests := []struct {
+		schedule DirectQueryRefreshSchedule
+		wantErr  string
+	}{
+		{DirectQueryRefreshSchedule{Frequency: 30, LocalTimeZoneID: "W. Europe Standard Time"}, ""},
+		{DirectQueryRefreshSchedule{Days: []Day{DaySunday}, Times: []string{"00:30"}}, ""},
+		{DirectQueryRefreshSchedule{Frequency: 45}, "frequency"},
+		{DirectQueryRefreshSchedule{Frequency: 15, Days: []Day{DaySunday}}, "combined"},
+		{DirectQueryRefreshSchedule{Frequency: 15, LocalTimeZoneID: "CET"}, "time zone"},
+	}
+	for _, tt := range tests {
+		err := tt.schedule.Validate()
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%+v: Validate() = %v, want error containing %q", tt.schedule, err, tt.wantErr)
+		}
+	}
+}
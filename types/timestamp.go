@@ -0,0 +1,55 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayouts are the layouts of the timestamps of the Power BI REST
+// APIs, which omit the time zone of UTC times in some responses.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// Timestamp is a time of the Power BI REST APIs. Timestamps without a time
+// zone are in UTC. An empty or null timestamp decodes as the zero time.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("types: invalid timestamp %q", s)
+}
+
+// MarshalJSON implements json.Marshaler. The zero time is encoded as null.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
+
+func (t Timestamp) String() string {
+	return t.Time.String()
+}
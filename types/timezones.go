@@ -0,0 +1,147 @@
+package types
+
+// windowsTimeZones are the Windows time zone IDs accepted by refresh
+// schedules, as listed by tzutil /l.
+var windowsTimeZones = map[string]bool{
+	"Dateline Standard Time":          true,
+	"UTC-11":                          true,
+	"Aleutian Standard Time":          true,
+	"Hawaiian Standard Time":          true,
+	"Marquesas Standard Time":         true,
+	"Alaskan Standard Time":           true,
+	"UTC-09":                          true,
+	"Pacific Standard Time (Mexico)":  true,
+	"UTC-08":                          true,
+	"Pacific Standard Time":           true,
+	"US Mountain Standard Time":       true,
+	"Mountain Standard Time (Mexico)": true,
+	"Mountain Standard Time":          true,
+	"Yukon Standard Time":             true,
+	"Central America Standard Time":   true,
+	"Central Standard Time":           true,
+	"Easter Island Standard Time":     true,
+	"Central Standard Time (Mexico)":  true,
+	"Canada Central Standard Time":    true,
+	"SA Pacific Standard Time":        true,
+	"Eastern Standard Time (Mexico)":  true,
+	"Eastern Standard Time":           true,
+	"Haiti Standard Time":             true,
+	"Cuba Standard Time":              true,
+	"US Eastern Standard Time":        true,
+	"Turks And Caicos Standard Time":  true,
+	"Paraguay Standard Time":          true,
+	"Atlantic Standard Time":          true,
+	"Venezuela Standard Time":         true,
+	"Central Brazilian Standard Time": true,
+	"SA Western Standard Time":        true,
+	"Pacific SA Standard Time":        true,
+	"Newfoundland Standard Time":      true,
+	"Tocantins Standard Time":         true,
+	"E. South America Standard Time":  true,
+	"SA Eastern Standard Time":        true,
+	"Argentina Standard Time":         true,
+	"Greenland Standard Time":         true,
+	"Montevideo Standard Time":        true,
+	"Magallanes Standard Time":        true,
+	"Saint Pierre Standard Time":      true,
+	"Bahia Standard Time":             true,
+	"UTC-02":                          true,
+	"Mid-Atlantic Standard Time":      true,
+	"Azores Standard Time":            true,
+	"Cape Verde Standard Time":        true,
+	"UTC":                             true,
+	"GMT Standard Time":               true,
+	"Greenwich Standard Time":         true,
+	"Sao Tome Standard Time":          true,
+	"Morocco Standard Time":           true,
+	"W. Europe Standard Time":         true,
+	"Central Europe Standard Time":    true,
+	"Romance Standard Time":           true,
+	"Central European Standard Time":  true,
+	"W. Central Africa Standard Time": true,
+	"Jordan Standard Time":            true,
+	"GTB Standard Time":               true,
+	"Middle East Standard Time":       true,
+	"Egypt Standard Time":             true,
+	"E. Europe Standard Time":         true,
+	"Syria Standard Time":             true,
+	"West Bank Standard Time":         true,
+	"South Africa Standard Time":      true,
+	"FLE Standard Time":               true,
+	"Israel Standard Time":            true,
+	"South Sudan Standard Time":       true,
+	"Kaliningrad Standard Time":       true,
+	"Sudan Standard Time":             true,
+	"Libya Standard Time":             true,
+	"Namibia Standard Time":           true,
+	"Arabic Standard Time":            true,
+	"Turkey Standard Time":            true,
+	"Arab Standard Time":              true,
+	"Belarus Standard Time":           true,
+	"Russian Standard Time":           true,
+	"E. Africa Standard Time":         true,
+	"Volgograd Standard Time":         true,
+	"Iran Standard Time":              true,
+	"Arabian Standard Time":           true,
+	"Astrakhan Standard Time":         true,
+	"Azerbaijan Standard Time":        true,
+	"Russia Time Zone 3":              true,
+	"Mauritius Standard Time":         true,
+	"Saratov Standard Time":           true,
+	"Georgian Standard Time":          true,
+	"Caucasus Standard Time":          true,
+	"Afghanistan Standard Time":       true,
+	"West Asia Standard Time":         true,
+	"Ekaterinburg Standard Time":      true,
+	"Pakistan Standard Time":          true,
+	"Qyzylorda Standard Time":         true,
+	"India Standard Time":             true,
+	"Sri Lanka Standard Time":         true,
+	"Nepal Standard Time":             true,
+	"Central Asia Standard Time":      true,
+	"Bangladesh Standard Time":        true,
+	"Omsk Standard Time":              true,
+	"Myanmar Standard Time":           true,
+	"SE Asia Standard Time":           true,
+	"Altai Standard Time":             true,
+	"W. Mongolia Standard Time":       true,
+	"North Asia Standard Time":        true,
+	"N. Central Asia Standard Time":   true,
+	"Tomsk Standard Time":             true,
+	"China Standard Time":             true,
+	"North Asia East Standard Time":   true,
+	"Singapore Standard Time":         true,
+	"W. Australia Standard Time":      true,
+	"Taipei Standard Time":            true,
+	"Ulaanbaatar Standard Time":       true,
+	"Aus Central W. Standard Time":    true,
+	"Transbaikal Standard Time":       true,
+	"Tokyo Standard Time":             true,
+	"North Korea Standard Time":       true,
+	"Korea Standard Time":             true,
+	"Yakutsk Standard Time":           true,
+	"Cen. Australia Standard Time":    true,
+	"AUS Central Standard Time":       true,
+	"E. Australia Standard Time":      true,
+	"AUS Eastern Standard Time":       true,
+	"West Pacific Standard Time":      true,
+	"Tasmania Standard Time":          true,
+	"Vladivostok Standard Time":       true,
+	"Lord Howe Standard Time":         true,
+	"Bougainville Standard Time":      true,
+	"Russia Time Zone 10":             true,
+	"Magadan Standard Time":           true,
+	"Norfolk Standard Time":           true,
+	"Sakhalin Standard Time":          true,
+	"Central Pacific Standard Time":   true,
+	"Russia Time Zone 11":             true,
+	"New Zealand Standard Time":       true,
+	"UTC+12":                          true,
+	"Fiji Standard Time":              true,
+	"Kamchatka Standard Time":         true,
+	"Chatham Islands Standard Time":   true,
+	"UTC+13":                          true,
+	"Tonga Standard Time":             true,
+	"Samoa Standard Time":             true,
+	"Line Islands Standard Time":      true,
+}